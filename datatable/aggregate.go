@@ -0,0 +1,127 @@
+package datatable
+
+import (
+	"fmt"
+)
+
+// GroupBy groups the rows of the data table by the values of field. It returns
+// a map of each distinct value to a new data table containing the matching
+// rows. The new data tables have the same fields and options as the receiver,
+// which is left unchanged. Returns an error if the field does not exist.
+func (t *DataTable) GroupBy(field string) (map[string]*DataTable, error) {
+	col, err := t.lookupField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*DataTable)
+
+	for _, row := range t.rows {
+		value := row[col]
+
+		group, ok := groups[value]
+		if !ok {
+			group = t.derive(make([][]string, 0))
+			groups[value] = group
+		}
+
+		group.rows = append(group.rows, copyValues(row))
+	}
+
+	return groups, nil
+}
+
+// Count returns a map of each distinct value of field to the number of rows
+// containing it. Returns an error if the field does not exist.
+func (t *DataTable) Count(field string) (map[string]int, error) {
+	col, err := t.lookupField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, row := range t.rows {
+		counts[row[col]]++
+	}
+
+	return counts, nil
+}
+
+// Sum returns the sum of the values of field parsed as float64. Returns 0 if
+// the data table has no rows. Returns an error if the field does not exist or
+// if any value cannot be parsed.
+func (t *DataTable) Sum(field string) (float64, error) {
+	values, err := t.FloatColumn(field)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum, nil
+}
+
+// Min returns the smallest value of field parsed as float64. Returns an error
+// if the field does not exist, if any value cannot be parsed or if the data
+// table has no rows.
+func (t *DataTable) Min(field string) (float64, error) {
+	return t.reduce(field, func(a, b float64) float64 {
+		if b < a {
+			return b
+		}
+
+		return a
+	})
+}
+
+// Max returns the largest value of field parsed as float64. Returns an error
+// if the field does not exist, if any value cannot be parsed or if the data
+// table has no rows.
+func (t *DataTable) Max(field string) (float64, error) {
+	return t.reduce(field, func(a, b float64) float64 {
+		if b > a {
+			return b
+		}
+
+		return a
+	})
+}
+
+// Average returns the arithmetic mean of the values of field parsed as
+// float64. Returns an error if the field does not exist, if any value cannot
+// be parsed or if the data table has no rows.
+func (t *DataTable) Average(field string) (float64, error) {
+	sum, err := t.Sum(field)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(t.rows) == 0 {
+		return 0, fmt.Errorf("cannot compute average of field %q in data table without rows", field)
+	}
+
+	return sum / float64(len(t.rows)), nil
+}
+
+// reduce parses the values of field as float64 and combines them using fn.
+// Returns an error if the data table has no rows.
+func (t *DataTable) reduce(field string, fn func(a, b float64) float64) (float64, error) {
+	values, err := t.FloatColumn(field)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(values) == 0 {
+		return 0, fmt.Errorf("cannot aggregate field %q in data table without rows", field)
+	}
+
+	result := values[0]
+	for _, value := range values[1:] {
+		result = fn(result, value)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "status"},
+		[]string{"foo", "active"},
+		[]string{"bar", "inactive"},
+		[]string{"baz", "active"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	groups, err := dt.GroupBy("status")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := map[string][][]string{
+		"active":   {{"foo", "active"}, {"baz", "active"}},
+		"inactive": {{"bar", "inactive"}},
+	}
+
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d groups, got %d", len(expected), len(groups))
+	}
+
+	for value, rows := range expected {
+		group, ok := groups[value]
+		if !ok {
+			t.Fatalf("expected group for value %q", value)
+		}
+
+		if !reflect.DeepEqual(group.RowValues(), rows) {
+			t.Fatalf("expected rows %#v for group %q, got %#v", rows, value, group.RowValues())
+		}
+
+		if !reflect.DeepEqual(group.Fields(), dt.Fields()) {
+			t.Fatalf("expected fields %#v, got %#v", dt.Fields(), group.Fields())
+		}
+	}
+
+	if _, err := dt.GroupBy("unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestCount(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "status"},
+		[]string{"foo", "failed"},
+		[]string{"bar", "passed"},
+		[]string{"baz", "failed"},
+		[]string{"qux", ""},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	counts, err := dt.Count("status")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := map[string]int{"failed": 2, "passed": 1, "": 1}
+
+	if !reflect.DeepEqual(counts, expected) {
+		t.Fatalf("expected counts %#v, got %#v", expected, counts)
+	}
+
+	if _, err := dt.Count("unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestNumericAggregations(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "amount"},
+		[]string{"foo", "10"},
+		[]string{"bar", "-2.5"},
+		[]string{"baz", "40"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name     string
+		fn       func(field string) (float64, error)
+		expected float64
+	}{
+		{name: "sum", fn: dt.Sum, expected: 47.5},
+		{name: "min", fn: dt.Min, expected: -2.5},
+		{name: "max", fn: dt.Max, expected: 40},
+		{name: "average", fn: dt.Average, expected: 47.5 / 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.fn("amount")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if result != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, result)
+			}
+
+			if _, err := tc.fn("name"); err == nil {
+				t.Fatal("expected error for non-numeric values but got nil")
+			}
+
+			if _, err := tc.fn("unknown"); err == nil {
+				t.Fatal("expected error for unknown field but got nil")
+			}
+		})
+	}
+}
+
+func TestNumericAggregationsEmpty(t *testing.T) {
+	dt, err := New([]string{"amount"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	sum, err := dt.Sum("amount")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if sum != 0 {
+		t.Fatalf("expected 0, got %v", sum)
+	}
+
+	for _, fn := range []func(string) (float64, error){dt.Min, dt.Max, dt.Average} {
+		if _, err := fn("amount"); err == nil {
+			t.Fatal("expected error but got nil")
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Builder provides a fluent way to construct a data table. Errors are
+// collected while building and reported all at once by Build. The zero value
+// is ready to use.
+type Builder struct {
+	fields    []string
+	rows      [][]string
+	options   *Options
+	fieldsSet bool
+	errs      []error
+}
+
+// NewBuilder creates a new *Builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Fields sets the fields of the data table. It must be called exactly once
+// before any rows are added.
+func (b *Builder) Fields(fields ...string) *Builder {
+	switch {
+	case b.fieldsSet:
+		b.errs = append(b.errs, errors.New("fields were already set"))
+	case len(b.rows) > 0:
+		b.errs = append(b.errs, errors.New("fields must be set before adding rows"))
+	default:
+		b.fields = fields
+		b.fieldsSet = true
+	}
+
+	return b
+}
+
+// Row adds a row to the data table. Rows whose length does not match the
+// number of fields are rejected.
+func (b *Builder) Row(row ...string) *Builder {
+	index := len(b.rows)
+
+	if len(row) != len(b.fields) {
+		b.errs = append(b.errs, fmt.Errorf("row %d: expected row length of %d, got %d", index, len(b.fields), len(row)))
+	}
+
+	b.rows = append(b.rows, row)
+
+	return b
+}
+
+// Option sets the options of the data table.
+func (b *Builder) Option(options *Options) *Builder {
+	b.options = options
+
+	return b
+}
+
+// Build creates the data table. Returns a *ValidationError listing all
+// problems that were encountered while building, or the error returned by
+// NewWithOptions.
+func (b *Builder) Build() (*DataTable, error) {
+	if !b.fieldsSet {
+		b.errs = append(b.errs, errors.New("fields were not set"))
+	}
+
+	if len(b.errs) > 0 {
+		return nil, &ValidationError{Errors: b.errs}
+	}
+
+	return NewWithOptions(b.options, b.fields, b.rows...)
+}
@@ -0,0 +1,79 @@
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	dt, err := NewBuilder().
+		Fields("name", "value").
+		Option(&Options{FieldTypes: map[string]FieldType{"value": Int}}).
+		Row("foo", "1").
+		Row("bar", "2").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"foo", "1"}, {"bar", "2"}}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+
+	if dt.options == nil {
+		t.Fatal("expected options to be set")
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		builder  *Builder
+		expected string
+	}{
+		{
+			name:     "mismatched rows",
+			builder:  NewBuilder().Fields("name", "value").Row("foo").Row("bar", "2").Row("baz", "3", "4"),
+			expected: "row 0: expected row length of 2, got 1; row 2: expected row length of 2, got 3",
+		},
+		{
+			name:     "no fields",
+			builder:  NewBuilder(),
+			expected: "fields were not set",
+		},
+		{
+			name:     "fields set twice",
+			builder:  NewBuilder().Fields("name").Fields("value"),
+			expected: "fields were already set",
+		},
+		{
+			name:     "fields set after rows",
+			builder:  NewBuilder().Row().Fields("name"),
+			expected: "fields must be set before adding rows; fields were not set",
+		},
+		{
+			name:     "validation error",
+			builder:  NewBuilder().Fields("name").Option(&Options{RequiredFields: []string{"value"}}),
+			expected: `data table is missing required field "value"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build()
+			if err == nil {
+				t.Fatal("expected error but got nil")
+			}
+
+			if err.Error() != tc.expected {
+				t.Fatalf("expected error %q, got %q", tc.expected, err.Error())
+			}
+
+			if _, ok := err.(*ValidationError); !ok {
+				t.Fatalf("expected *ValidationError, got %T", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package datatable
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// IntColumn returns the values of all rows for given field parsed as int.
+// Returns an error if the field does not exist or if any value cannot be
+// parsed.
+func (t *DataTable) IntColumn(field string) ([]int, error) {
+	values, err := t.Column(field)
+	if err != nil {
+		return nil, err
+	}
+
+	ints := make([]int, len(values))
+	for i, value := range values {
+		if ints[i], err = strconv.Atoi(value); err != nil {
+			return nil, parseError(i, t.fields[t.fieldIndex(field)], value, Int)
+		}
+	}
+
+	return ints, nil
+}
+
+// FloatColumn returns the values of all rows for given field parsed as
+// float64. Returns an error if the field does not exist or if any value cannot
+// be parsed.
+func (t *DataTable) FloatColumn(field string) ([]float64, error) {
+	values, err := t.Column(field)
+	if err != nil {
+		return nil, err
+	}
+
+	floats := make([]float64, len(values))
+	for i, value := range values {
+		if floats[i], err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, parseError(i, t.fields[t.fieldIndex(field)], value, Float)
+		}
+	}
+
+	return floats, nil
+}
+
+// BoolColumn returns the values of all rows for given field parsed as bool.
+// Accepted values are those accepted by strconv.ParseBool. Returns an error if
+// the field does not exist or if any value cannot be parsed.
+func (t *DataTable) BoolColumn(field string) ([]bool, error) {
+	values, err := t.Column(field)
+	if err != nil {
+		return nil, err
+	}
+
+	bools := make([]bool, len(values))
+	for i, value := range values {
+		if bools[i], err = strconv.ParseBool(value); err != nil {
+			return nil, parseError(i, t.fields[t.fieldIndex(field)], value, Bool)
+		}
+	}
+
+	return bools, nil
+}
+
+// GetInt returns the value of the cell in the row at rowIndex for given field
+// parsed as int. Returns an error if the row index is out of bounds, the field
+// does not exist or the value cannot be parsed.
+func (t *DataTable) GetInt(rowIndex int, field string) (int, error) {
+	value, err := t.GetCell(rowIndex, field)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, parseError(rowIndex, t.fields[t.fieldIndex(field)], value, Int)
+	}
+
+	return i, nil
+}
+
+// GetFloat returns the value of the cell in the row at rowIndex for given
+// field parsed as float64. Returns an error if the row index is out of bounds,
+// the field does not exist or the value cannot be parsed.
+func (t *DataTable) GetFloat(rowIndex int, field string) (float64, error) {
+	value, err := t.GetCell(rowIndex, field)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, parseError(rowIndex, t.fields[t.fieldIndex(field)], value, Float)
+	}
+
+	return f, nil
+}
+
+// GetBool returns the value of the cell in the row at rowIndex for given field
+// parsed as bool. Accepted values are those accepted by strconv.ParseBool.
+// Returns an error if the row index is out of bounds, the field does not exist
+// or the value cannot be parsed.
+func (t *DataTable) GetBool(rowIndex int, field string) (bool, error) {
+	value, err := t.GetCell(rowIndex, field)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, parseError(rowIndex, t.fields[t.fieldIndex(field)], value, Bool)
+	}
+
+	return b, nil
+}
+
+// InferTypes returns the narrowest field type that all non-empty values of a
+// field can be parsed as. Candidates are tried in the order Int, Float and
+// Bool. Fields that do not match any of them or only contain empty values are
+// reported as String. Duplicate fields are suffixed in the same way as in
+// Rows.
+func (t *DataTable) InferTypes() map[string]FieldType {
+	keys := t.mapKeys()
+	types := make(map[string]FieldType, len(keys))
+
+	for col, key := range keys {
+		types[key] = t.inferType(col)
+	}
+
+	return types
+}
+
+// inferType returns the narrowest field type for the values in column col.
+func (t *DataTable) inferType(col int) FieldType {
+	for _, fieldType := range []FieldType{Int, Float, Bool} {
+		if t.columnMatches(col, fieldType) {
+			return fieldType
+		}
+	}
+
+	return String
+}
+
+// columnMatches returns true if column col contains at least one non-empty
+// value and all non-empty values are valid for fieldType.
+func (t *DataTable) columnMatches(col int, fieldType FieldType) bool {
+	empty := true
+
+	for _, row := range t.rows {
+		if row[col] == "" {
+			continue
+		}
+
+		if !fieldType.valid(row[col]) {
+			return false
+		}
+
+		empty = false
+	}
+
+	return !empty
+}
+
+// parseError returns an error for a value in given row and field that cannot
+// be parsed as fieldType.
+func parseError(row int, field, value string, fieldType FieldType) error {
+	return fmt.Errorf("row %d, field %q: value %q is not a valid %s", row, field, value, fieldType)
+}
@@ -0,0 +1,231 @@
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypedColumns(t *testing.T) {
+	dt, err := New(
+		[]string{"count", "ratio", "enabled", "name"},
+		[]string{"1", "0.5", "true", "foo"},
+		[]string{"-2", "3", "0", "bar"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	ints, err := dt.IntColumn("count")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if expected := []int{1, -2}; !reflect.DeepEqual(ints, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, ints)
+	}
+
+	floats, err := dt.FloatColumn("ratio")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if expected := []float64{0.5, 3}; !reflect.DeepEqual(floats, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, floats)
+	}
+
+	bools, err := dt.BoolColumn("enabled")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if expected := []bool{true, false}; !reflect.DeepEqual(bools, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, bools)
+	}
+}
+
+func TestTypedColumnErrors(t *testing.T) {
+	dt, err := New(
+		[]string{"count", "name"},
+		[]string{"1", "foo"},
+		[]string{"1.5", "bar"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name     string
+		fn       func() error
+		expected string
+	}{
+		{
+			name: "int",
+			fn: func() error {
+				_, err := dt.IntColumn("count")
+				return err
+			},
+			expected: `row 1, field "count": value "1.5" is not a valid int`,
+		},
+		{
+			name: "float",
+			fn: func() error {
+				_, err := dt.FloatColumn("name")
+				return err
+			},
+			expected: `row 0, field "name": value "foo" is not a valid float`,
+		},
+		{
+			name: "bool",
+			fn: func() error {
+				_, err := dt.BoolColumn("count")
+				return err
+			},
+			expected: `row 1, field "count": value "1.5" is not a valid bool`,
+		},
+		{
+			name: "unknown field",
+			fn: func() error {
+				_, err := dt.IntColumn("unknown")
+				return err
+			},
+			expected: `data table does not contain field "unknown"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.fn()
+			if err == nil {
+				t.Fatal("expected error but got nil")
+			}
+
+			if err.Error() != tc.expected {
+				t.Fatalf("expected error %q, got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestTypedGetters(t *testing.T) {
+	dt, err := New(
+		[]string{"count", "ratio", "enabled"},
+		[]string{"42", "0.25", "false"},
+		[]string{"x", "y", "z"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	i, err := dt.GetInt(0, "count")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if i != 42 {
+		t.Fatalf("expected 42, got %d", i)
+	}
+
+	f, err := dt.GetFloat(0, "ratio")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if f != 0.25 {
+		t.Fatalf("expected 0.25, got %v", f)
+	}
+
+	b, err := dt.GetBool(0, "enabled")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if b {
+		t.Fatal("expected false, got true")
+	}
+
+	cases := []struct {
+		name     string
+		fn       func() error
+		expected string
+	}{
+		{
+			name: "int",
+			fn: func() error {
+				_, err := dt.GetInt(1, "count")
+				return err
+			},
+			expected: `row 1, field "count": value "x" is not a valid int`,
+		},
+		{
+			name: "float",
+			fn: func() error {
+				_, err := dt.GetFloat(1, "ratio")
+				return err
+			},
+			expected: `row 1, field "ratio": value "y" is not a valid float`,
+		},
+		{
+			name: "bool",
+			fn: func() error {
+				_, err := dt.GetBool(1, "enabled")
+				return err
+			},
+			expected: `row 1, field "enabled": value "z" is not a valid bool`,
+		},
+		{
+			name: "row out of range",
+			fn: func() error {
+				_, err := dt.GetInt(2, "count")
+				return err
+			},
+			expected: `row index 2 out of range, data table has 2 rows`,
+		},
+		{
+			name: "unknown field",
+			fn: func() error {
+				_, err := dt.GetBool(0, "unknown")
+				return err
+			},
+			expected: `data table does not contain field "unknown"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.fn()
+			if err == nil {
+				t.Fatal("expected error but got nil")
+			}
+
+			if err.Error() != tc.expected {
+				t.Fatalf("expected error %q, got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestInferTypes(t *testing.T) {
+	dt, err := New(
+		[]string{"int", "float", "bool", "string", "empty", "mixed", "floatbool"},
+		[]string{"1", "1.5", "true", "foo", "", "1", "1.5"},
+		[]string{"", "2", "false", "", "", "1.5", "true"},
+		[]string{"-3", "", "", "bar", "", "yes", ""},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := map[string]FieldType{
+		"int":       Int,
+		"float":     Float,
+		"bool":      Bool,
+		"string":    String,
+		"empty":     String,
+		"mixed":     String,
+		"floatbool": String,
+	}
+
+	if types := dt.InferTypes(); !reflect.DeepEqual(types, expected) {
+		t.Fatalf("expected types %#v, got %#v", expected, types)
+	}
+}
@@ -0,0 +1,129 @@
+package datatable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+// FromCSV creates a new DataTable from the csv data read from r. The first
+// record is used as the data table fields, all following records are used as
+// rows.
+func FromCSV(r io.Reader) (*DataTable, error) {
+	return FromCSVWithOptions(nil, r)
+}
+
+// FromCSVWithOptions creates a new DataTable with options from the csv data
+// read from r. The first record is used as the data table fields, all
+// following records are used as rows.
+func FromCSVWithOptions(options *Options, r io.Reader) (*DataTable, error) {
+	cr := csv.NewReader(r)
+
+	return fromRecords(options, cr, "csv")
+}
+
+// FromTSV creates a new DataTable from the tab-separated data read from r. The
+// first record is used as the data table fields, all following records are
+// used as rows. Quotes appearing in unquoted fields are tolerated.
+func FromTSV(r io.Reader) (*DataTable, error) {
+	return FromTSVWithOptions(nil, r)
+}
+
+// FromTSVWithOptions creates a new DataTable with options from the
+// tab-separated data read from r. The first record is used as the data table
+// fields, all following records are used as rows. Quotes appearing in unquoted
+// fields are tolerated.
+func FromTSVWithOptions(options *Options, r io.Reader) (*DataTable, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = '\t'
+	cr.LazyQuotes = true
+
+	return fromRecords(options, cr, "tsv")
+}
+
+// ToCSV transforms the data table into its csv representation. The first
+// line contains the data table fields.
+func (t *DataTable) ToCSV() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := t.WriteCSV(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteCSV writes the csv representation of the data table to w. The first
+// line contains the data table fields.
+func (t *DataTable) WriteCSV(w io.Writer) error {
+	return t.writeRecords(w, ',')
+}
+
+// ToTSV transforms the data table into its tab-separated representation. The
+// first line contains the data table fields.
+func (t *DataTable) ToTSV() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := t.WriteTSV(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteTSV writes the tab-separated representation of the data table to w.
+// The first line contains the data table fields.
+func (t *DataTable) WriteTSV(w io.Writer) error {
+	return t.writeRecords(w, '\t')
+}
+
+// fromRecords creates a new DataTable with options from all records read from
+// cr. The format is only used in error messages.
+func fromRecords(options *Options, cr *csv.Reader, format string) (*DataTable, error) {
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New(format + " data must contain at least a header record")
+	}
+
+	return NewWithOptions(options, records[0], records[1:]...)
+}
+
+// writeRecords writes the data table fields followed by all rows to w using
+// comma as field delimiter. Records consisting of a single empty value are
+// written as a quoted empty string because encoding/csv would otherwise
+// write an empty line which is skipped when reading the data back.
+func (t *DataTable) writeRecords(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	records := append([][]string{t.fields}, t.rows...)
+
+	for _, record := range records {
+		if len(record) != 1 || record[0] != "" {
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		cw.Flush()
+
+		if err := cw.Error(); err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, "\"\"\n"); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,180 @@
+package datatable
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromCSV(t *testing.T) {
+	dt, err := FromCSV(strings.NewReader("name,value\nfoo,bar\n\"with,comma\",\"with\nnewline\"\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "value"}
+	expectedRows := [][]string{{"foo", "bar"}, {"with,comma", "with\nnewline"}}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+}
+
+func TestFromCSVWithOptions(t *testing.T) {
+	cases := []struct {
+		name        string
+		options     *Options
+		data        string
+		expectError bool
+	}{
+		{
+			name:    "valid",
+			options: &Options{RequiredFields: []string{"name"}},
+			data:    "name,value\nfoo,bar\n",
+		},
+		{
+			name:        "missing required field",
+			options:     &Options{RequiredFields: []string{"name"}},
+			data:        "key,value\nfoo,bar\n",
+			expectError: true,
+		},
+		{
+			name:        "empty",
+			data:        "",
+			expectError: true,
+		},
+		{
+			name:        "wrong number of fields",
+			data:        "name,value\nfoo\n",
+			expectError: true,
+		},
+		{
+			name:        "malformed",
+			data:        "name,value\n\"foo,bar\n",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := FromCSVWithOptions(tc.options, strings.NewReader(tc.data))
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"foo", "bar"},
+		[]string{"with,comma", `with "quotes"`},
+		[]string{"with\nnewline", ""},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	buf, err := dt.ToCSV()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := "name,value\nfoo,bar\n\"with,comma\",\"with \"\"quotes\"\"\"\n\"with\nnewline\",\n"
+
+	if string(buf) != expected {
+		t.Fatalf("expected %q, got %q", expected, string(buf))
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestWriteCSVError(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.WriteCSV(errWriter{}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestFromTSV(t *testing.T) {
+	dt, err := FromTSV(strings.NewReader("name\tvalue\nfoo,bar\ta \"quoted\" value\n\"with\ttab\"\t\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "value"}
+	expectedRows := [][]string{{"foo,bar", `a "quoted" value`}, {"with\ttab", ""}}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+
+	if _, err := FromTSV(strings.NewReader("")); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	options := &Options{RequiredFields: []string{"id"}}
+
+	if _, err := FromTSVWithOptions(options, strings.NewReader("name\tvalue\n")); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestToTSV(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"foo,bar", "baz"},
+		[]string{"with\ttab", ""},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	buf, err := dt.ToTSV()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := "name\tvalue\nfoo,bar\tbaz\n\"with\ttab\"\t\n"
+
+	if string(buf) != expected {
+		t.Fatalf("expected %q, got %q", expected, string(buf))
+	}
+
+	result, err := FromTSV(strings.NewReader(string(buf)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !result.Equal(dt) {
+		t.Fatalf("expected %#v, got %#v", dt.RowValues(), result.RowValues())
+	}
+
+	if err := dt.WriteTSV(errWriter{}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
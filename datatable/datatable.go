@@ -3,13 +3,13 @@
 package datatable
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/DATA-DOG/godog/gherkin"
-	"github.com/jinzhu/copier"
 	"github.com/tidwall/pretty"
 )
 
@@ -17,6 +17,79 @@ import (
 type Options struct {
 	OptionalFields []string
 	RequiredFields []string
+
+	// Strict rejects all fields that are not listed in RequiredFields or
+	// OptionalFields. Without Strict, additional fields are only rejected if
+	// OptionalFields is not empty, so Strict allows to require exactly the
+	// fields listed in RequiredFields without repeating them in
+	// OptionalFields. Strict has no effect if both RequiredFields and
+	// OptionalFields are empty.
+	Strict bool
+
+	// OrderedFields lists fields in the order they must appear in the data
+	// table. By default the fields of the data table must match OrderedFields
+	// exactly. If OrderedFieldsPrefix is set, the data table may contain
+	// additional fields after the ordered ones.
+	OrderedFields       []string
+	OrderedFieldsPrefix bool
+
+	// NonEmptyFields lists fields whose values must not be empty. Fields that
+	// are not present are ignored, use RequiredFields to enforce presence.
+	NonEmptyFields []string
+
+	// FieldTypes maps field names to the type their values must have. Empty
+	// values are not checked.
+	FieldTypes map[string]FieldType
+
+	// FieldPatterns maps field names to patterns their values must fully
+	// match. Empty values are only checked if the field is also required.
+	FieldPatterns map[string]*regexp.Regexp
+
+	// UniqueFields lists fields whose values must be unique across all rows.
+	UniqueFields []string
+
+	// UniqueKeys lists combinations of fields whose values must be unique
+	// across all rows.
+	UniqueKeys [][]string
+
+	// CaseInsensitiveFields enables case-insensitive matching of field names
+	// for all options and for field lookups, e.g. via Column or GetCell.
+	// Data tables containing fields that only differ in case are rejected
+	// if set.
+	CaseInsensitiveFields bool
+
+	// Aliases maps alternative field names to their canonical field name.
+	// Aliased fields are renamed to their canonical name upon creation of
+	// the data table, before any validation takes place. Field lookups
+	// accept both aliases and canonical names.
+	Aliases map[string]string
+
+	// Defaults maps field names to default values. Missing fields are added
+	// to the data table upon creation and populated with their default value.
+	// If the field is present, only its empty values are replaced by the
+	// default value.
+	Defaults map[string]string
+
+	// Trim enables trimming of leading and trailing whitespace of all field
+	// names and values upon creation of the data table. Trimming happens
+	// before aliases and defaults are applied and before any validation takes
+	// place.
+	Trim bool
+
+	// AllowDuplicateFields allows fields with the same name. By default, data
+	// tables containing duplicate fields are rejected. If set, the duplicates
+	// are suffixed with their occurrence in map representations of rows, e.g.
+	// "name" and "name_2", so that no values are lost.
+	AllowDuplicateFields bool
+
+	// SkipCommentRows enables skipping of data rows whose first cell starts
+	// with CommentPrefix when creating a data table via
+	// FromGherkinWithOptions. The header row is never treated as a comment.
+	SkipCommentRows bool
+
+	// CommentPrefix is the prefix that marks comment rows if SkipCommentRows
+	// is set. Defaults to "#" if empty.
+	CommentPrefix string
 }
 
 // DataTable defines a table with fields names and rows.
@@ -25,6 +98,10 @@ type DataTable struct {
 	rows   [][]string
 
 	options *Options
+
+	index *rowIndex
+
+	err error
 }
 
 // New creates a new DataTable with given fields. It optionally accepts initial
@@ -42,13 +119,17 @@ func NewWithOptions(options *Options, fields []string, rows ...[]string) (*DataT
 		}
 	}
 
+	fields, rows = options.trim(fields, rows)
+	fields = options.canonicalFields(fields)
+	fields, rows = options.applyDefaults(fields, rows)
+
 	dt := &DataTable{
 		fields:  fields,
 		rows:    rows,
 		options: options,
 	}
 
-	if err := dt.validateFields(); err != nil {
+	if err := dt.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -60,59 +141,127 @@ func FromGherkin(dt *gherkin.DataTable) (*DataTable, error) {
 	return FromGherkinWithOptions(nil, dt)
 }
 
-// FromGherkinWithOptions creates a new DataTable from *gherkin.DataTable with options.
+// FromGherkinWithOptions creates a new DataTable from *gherkin.DataTable with
+// options. Returns an error if dt is nil, which is the case for optional step
+// arguments that were omitted.
 func FromGherkinWithOptions(options *Options, dt *gherkin.DataTable) (*DataTable, error) {
+	if dt == nil {
+		return nil, errors.New("nil gherkin data table")
+	}
+
 	if len(dt.Rows) < 2 {
 		return nil, errors.New("data table must have at least two rows")
 	}
 
-	return NewWithOptions(options, values(dt.Rows[0]), rowValues(dt.Rows[1:])...)
+	rows := rowValues(dt.Rows[1:])
+	if options != nil && options.SkipCommentRows {
+		rows = skipCommentRows(rows, options.commentPrefix())
+	}
+
+	return NewWithOptions(options, values(dt.Rows[0]), rows...)
 }
 
-// validateFields ensures that required fields are present and there are only
-// fields listed in the AllowedFields option if the data table was created with
-// options.
-func (t *DataTable) validateFields() error {
-	if t.options == nil {
-		return nil
+// FromGherkinBody creates a new DataTable from a *gherkin.DataTable without a
+// header row. All rows of dt are treated as data and paired with fields.
+// Returns an error if the length of any row does not match the number of
+// fields.
+func FromGherkinBody(fields []string, dt *gherkin.DataTable) (*DataTable, error) {
+	if dt == nil {
+		return nil, errors.New("nil gherkin data table")
 	}
 
-	for _, field := range t.options.RequiredFields {
-		if !contains(t.fields, field) {
-			return fmt.Errorf(`data table is missing required field %q`, field)
+	return New(fields, rowValues(dt.Rows)...)
+}
+
+// FromGherkinSchema creates a new DataTable from *gherkin.DataTable and
+// ensures that its fields exactly match schema, including their order.
+// Returns an error if they do not.
+func FromGherkinSchema(dt *gherkin.DataTable, schema []string) (*DataTable, error) {
+	if dt != nil && len(dt.Rows) > 0 {
+		if fields := values(dt.Rows[0]); !matchValues(fields, schema) {
+			return nil, fmt.Errorf(
+				`data table fields "%s" do not match schema "%s"`,
+				strings.Join(fields, `", "`),
+				strings.Join(schema, `", "`),
+			)
 		}
 	}
 
-	if len(t.options.OptionalFields) == 0 {
-		return nil
+	return FromGherkin(dt)
+}
+
+// commentPrefix returns the configured comment prefix or "#" if it is empty.
+func (o *Options) commentPrefix() string {
+	if o.CommentPrefix == "" {
+		return "#"
 	}
 
-	allowedFields := append(t.options.OptionalFields, t.options.RequiredFields...)
+	return o.CommentPrefix
+}
 
-	for _, field := range t.fields {
-		if !contains(allowedFields, field) {
-			return fmt.Errorf(
-				`data table contains additional field %q, allowed fields are "%s"`,
-				field,
-				strings.Join(allowedFields, `", "`),
-			)
-		}
+// ToGherkin converts the data table into a *gherkin.DataTable. The first row
+// of the result contains the data table fields.
+func (t *DataTable) ToGherkin() *gherkin.DataTable {
+	rows := make([]*gherkin.TableRow, len(t.rows)+1)
+
+	rows[0] = tableRow(t.fields)
+	for i, row := range t.rows {
+		rows[i+1] = tableRow(row)
 	}
 
-	return nil
+	return &gherkin.DataTable{Rows: rows}
 }
 
-// Copy makes a copy of the data table.
+// Copy makes a deep copy of the data table. Modifying the copy does not
+// affect the data table and vice versa.
 func (t *DataTable) Copy() *DataTable {
-	c := &DataTable{
-		fields: make([]string, len(t.fields)),
-		rows:   make([][]string, len(t.rows)),
+	return &DataTable{
+		fields:  copyValues(t.fields),
+		rows:    copyRows(t.rows),
+		options: t.options.copy(),
+	}
+}
+
+// copy makes a deep copy of the options. Compiled patterns are immutable and
+// therefore shared between the copies. Nil slices and maps stay nil. Returns
+// nil if o is nil.
+func (o *Options) copy() *Options {
+	if o == nil {
+		return nil
 	}
 
-	copier.Copy(&c.fields, &t.fields)
-	copier.Copy(&c.rows, &t.rows)
+	c := *o
+	c.OptionalFields = copyValues(o.OptionalFields)
+	c.RequiredFields = copyValues(o.RequiredFields)
+	c.OrderedFields = copyValues(o.OrderedFields)
+	c.NonEmptyFields = copyValues(o.NonEmptyFields)
+	c.UniqueFields = copyValues(o.UniqueFields)
 
-	return c
+	if o.UniqueKeys != nil {
+		c.UniqueKeys = make([][]string, len(o.UniqueKeys))
+		for i, key := range o.UniqueKeys {
+			c.UniqueKeys[i] = copyValues(key)
+		}
+	}
+
+	if o.FieldTypes != nil {
+		c.FieldTypes = make(map[string]FieldType, len(o.FieldTypes))
+		for field, fieldType := range o.FieldTypes {
+			c.FieldTypes[field] = fieldType
+		}
+	}
+
+	if o.FieldPatterns != nil {
+		c.FieldPatterns = make(map[string]*regexp.Regexp, len(o.FieldPatterns))
+		for field, pattern := range o.FieldPatterns {
+			c.FieldPatterns[field] = pattern
+		}
+	}
+
+	c.Aliases = copyMap(o.Aliases)
+	c.Defaults = copyMap(o.Defaults)
+
+	return &c
 }
 
 // FindRow compares given row with all rows in the data table and returns the
@@ -127,9 +276,132 @@ func (t *DataTable) FindRow(row []string) int {
 	return -1
 }
 
-// RemoveRow removes the row at given index.
-func (t *DataTable) RemoveRow(index int) {
+// FindRows compares given row with all rows in the data table and returns the
+// indices of all matching rows in ascending order. Returns an empty slice if
+// no row matches.
+func (t *DataTable) FindRows(row []string) []int {
+	indices := make([]int, 0)
+
+	for i, r := range t.rows {
+		if matchValues(r, row) {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// FindRowTrimmed is like FindRow but ignores leading and trailing whitespace
+// of the values on both sides when comparing rows.
+func (t *DataTable) FindRowTrimmed(row []string) int {
+	for i, r := range t.rows {
+		if matchValuesTrimmed(r, row) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// FindRowBy returns the index of the first row whose values match all values
+// in criteria. The keys of criteria are field names, fields not present in
+// criteria are ignored. Returns -1 if no row matches. Returns an error if
+// criteria contains fields that do not exist. Uses the index created via
+// BuildIndex if criteria references exactly the indexed fields.
+func (t *DataTable) FindRowBy(criteria map[string]string) (int, error) {
+	fields := make([]string, 0, len(criteria))
+	for field := range criteria {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	cols := make([]int, len(fields))
+	values := make([]string, len(fields))
+
+	for i, field := range fields {
+		col, err := t.lookupField(field)
+		if err != nil {
+			return -1, err
+		}
+
+		cols[i] = col
+		values[i] = criteria[field]
+	}
+
+	if index, ok := t.findIndexed(cols, values); ok {
+		return index, nil
+	}
+
+	for i, row := range t.rows {
+		if matchColumns(row, cols, values) {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// RemoveRow removes the row at given index. Will return an error if index is
+// out of bounds.
+func (t *DataTable) RemoveRow(index int) error {
+	if err := t.checkRowIndex(index); err != nil {
+		return err
+	}
+
 	t.rows = append(t.rows[:index], t.rows[index+1:]...)
+	t.invalidateIndex()
+
+	return nil
+}
+
+// RemoveRows removes the rows at all given indices. Indices refer to the row
+// positions before any removal and may be given in any order. Duplicate
+// indices are ignored. Will return an error if any index is out of bounds, in
+// which case no row is removed.
+func (t *DataTable) RemoveRows(indices ...int) error {
+	for _, index := range indices {
+		if err := t.checkRowIndex(index); err != nil {
+			return err
+		}
+	}
+
+	sorted := make([]int, len(indices))
+	copy(sorted, indices)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	for i, index := range sorted {
+		if i > 0 && index == sorted[i-1] {
+			continue
+		}
+
+		t.rows = append(t.rows[:index], t.rows[index+1:]...)
+	}
+
+	t.invalidateIndex()
+
+	return nil
+}
+
+// RemoveRowsWhere removes all rows for which predicate returns true and
+// returns the number of removed rows. It is the in-place counterpart of
+// Filter.
+func (t *DataTable) RemoveRowsWhere(predicate func(row map[string]string) bool) int {
+	rows := make([][]string, 0, len(t.rows))
+
+	for _, row := range t.rows {
+		if !predicate(t.rowMap(row)) {
+			rows = append(rows, row)
+		}
+	}
+
+	removed := len(t.rows) - len(rows)
+	if removed > 0 {
+		t.rows = rows
+		t.invalidateIndex()
+	}
+
+	return removed
 }
 
 // AppendRow appends a row to the data table. Will return an error if the
@@ -140,6 +412,227 @@ func (t *DataTable) AppendRow(row []string) error {
 	}
 
 	t.rows = append(t.rows, row)
+	t.invalidateIndex()
+
+	return nil
+}
+
+// AppendRows appends all rows to the data table. The length of every row is
+// checked before any row is appended, so that the data table is left
+// unchanged if one of the rows does not match the data table's fields. The
+// returned error contains the index of the offending row within rows.
+func (t *DataTable) AppendRows(rows ...[]string) error {
+	for i, row := range rows {
+		if len(row) != len(t.fields) {
+			return fmt.Errorf("row %d: expected row length of %d, got %d", i, len(t.fields), len(row))
+		}
+	}
+
+	t.rows = append(t.rows, rows...)
+	t.invalidateIndex()
+
+	return nil
+}
+
+// AppendRowMap appends a row to the data table whose values are taken from
+// row, which maps field names to values. Fields missing from row get an empty
+// value. Returns an error if row contains keys that are not fields of the
+// data table.
+func (t *DataTable) AppendRowMap(row map[string]string) error {
+	return t.appendRowMap(row, false)
+}
+
+// AppendRowMapStrict is like AppendRowMap but returns an error if row does not
+// contain a value for every field of the data table.
+func (t *DataTable) AppendRowMapStrict(row map[string]string) error {
+	return t.appendRowMap(row, true)
+}
+
+// appendRowMap converts row into a positional row and appends it. If strict is
+// true, all fields must be present in row.
+func (t *DataTable) appendRowMap(row map[string]string, strict bool) error {
+	keys := make([]string, 0, len(row))
+	for key := range row {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	values := make([]string, len(t.fields))
+	set := make([]bool, len(t.fields))
+
+	for _, key := range keys {
+		col, err := t.lookupField(key)
+		if err != nil {
+			return err
+		}
+
+		if set[col] {
+			return fmt.Errorf("row contains multiple values for field %q", t.fields[col])
+		}
+
+		values[col] = row[key]
+		set[col] = true
+	}
+
+	if strict {
+		for i, ok := range set {
+			if !ok {
+				return fmt.Errorf("row is missing a value for field %q", t.fields[i])
+			}
+		}
+	}
+
+	return t.AppendRow(values)
+}
+
+// WithRow appends a row consisting of given values to the data table and
+// returns the data table to allow chaining. If the number of values does not
+// match the data table's fields, no row is appended and the error is recorded.
+// Once an error was recorded, subsequent calls to WithRow have no effect. The
+// error can be retrieved via Err.
+func (t *DataTable) WithRow(row ...string) *DataTable {
+	if t.err == nil {
+		t.err = t.AppendRow(row)
+	}
+
+	return t
+}
+
+// Err returns the first error that was recorded by WithRow or nil.
+func (t *DataTable) Err() error {
+	return t.err
+}
+
+// UpdateRow replaces the row at index. Will return an error if index is out of
+// bounds or if the number of fields does not match the data table's fields.
+func (t *DataTable) UpdateRow(index int, row []string) error {
+	if err := t.checkRowIndex(index); err != nil {
+		return err
+	}
+
+	if len(row) != len(t.fields) {
+		return fmt.Errorf("expected row length of %d, got %d", len(t.fields), len(row))
+	}
+
+	t.rows[index] = row
+	t.invalidateIndex()
+
+	return nil
+}
+
+// InsertRow inserts a row at index. An index equal to the data table's length
+// appends the row. Will return an error if index is out of bounds or if the
+// number of fields does not match the data table's fields.
+func (t *DataTable) InsertRow(index int, row []string) error {
+	if len(row) != len(t.fields) {
+		return fmt.Errorf("expected row length of %d, got %d", len(t.fields), len(row))
+	}
+
+	if index < 0 || index > len(t.rows) {
+		return fmt.Errorf("row index %d out of range, data table has %d rows", index, len(t.rows))
+	}
+
+	t.rows = append(t.rows, nil)
+	copy(t.rows[index+1:], t.rows[index:])
+	t.rows[index] = row
+	t.invalidateIndex()
+
+	return nil
+}
+
+// Clear removes all rows from the data table. Fields and options are kept.
+func (t *DataTable) Clear() {
+	t.rows = make([][]string, 0)
+	t.invalidateIndex()
+}
+
+// RemoveColumn removes field and the corresponding value of every row from
+// the data table. Returns an error if the field does not exist or if the
+// remaining fields are not allowed by the data table's options, e.g. because
+// field is required.
+func (t *DataTable) RemoveColumn(field string) error {
+	col, err := t.lookupField(field)
+	if err != nil {
+		return err
+	}
+
+	fields := removeValue(t.fields, col)
+
+	if err := validateFields(t.options, fields); err != nil {
+		return err
+	}
+
+	t.fields = fields
+
+	for i, row := range t.rows {
+		t.rows[i] = removeValue(row, col)
+	}
+
+	t.invalidateIndex()
+
+	return nil
+}
+
+// AppendColumn appends field to the data table and sets its value to
+// defaultValue in every row. Returns an error if the field already exists or
+// if it is not allowed by the data table's options.
+func (t *DataTable) AppendColumn(field, defaultValue string) error {
+	field = t.options.canonical(field)
+
+	if t.fieldIndex(field) >= 0 {
+		return fmt.Errorf("data table already contains field %q", field)
+	}
+
+	fields := make([]string, len(t.fields), len(t.fields)+1)
+	copy(fields, t.fields)
+	fields = append(fields, field)
+
+	if err := validateFields(t.options, fields); err != nil {
+		return err
+	}
+
+	t.fields = fields
+
+	for i, row := range t.rows {
+		t.rows[i] = append(row, defaultValue)
+	}
+
+	t.invalidateIndex()
+
+	return nil
+}
+
+// RenameColumn renames the field oldName to newName while leaving the row
+// values untouched. Returns an error if oldName does not exist, if newName
+// already exists or if the renamed fields are not allowed by the data table's
+// options.
+func (t *DataTable) RenameColumn(oldName, newName string) error {
+	col, err := t.lookupField(oldName)
+	if err != nil {
+		return err
+	}
+
+	newName = t.options.canonical(newName)
+
+	if t.fields[col] == newName {
+		return nil
+	}
+
+	if index := t.fieldIndex(newName); index >= 0 && index != col {
+		return fmt.Errorf("data table already contains field %q", newName)
+	}
+
+	fields := make([]string, len(t.fields))
+	copy(fields, t.fields)
+	fields[col] = newName
+
+	if err := validateFields(t.options, fields); err != nil {
+		return err
+	}
+
+	t.fields = fields
+	t.invalidateIndex()
 
 	return nil
 }
@@ -149,42 +642,282 @@ func (t *DataTable) Len() int {
 	return len(t.rows)
 }
 
+// NumFields returns the field count of the data table.
+func (t *DataTable) NumFields() int {
+	return len(t.fields)
+}
+
+// Shape returns the row count and the field count of the data table.
+func (t *DataTable) Shape() (rows, cols int) {
+	return len(t.rows), len(t.fields)
+}
+
+// Empty returns true if the data table has no rows.
+func (t *DataTable) Empty() bool {
+	return len(t.rows) == 0
+}
+
+// Options returns a copy of the options the data table was created with.
+// Returns nil if the data table has no options.
+func (t *DataTable) Options() *Options {
+	return t.options.copy()
+}
+
+// SetOptions replaces the options of the data table and revalidates it. If
+// the data table violates the new options, an error is returned and the
+// previous options are kept. Unlike NewWithOptions, SetOptions does not rename
+// aliased fields or apply defaults.
+func (t *DataTable) SetOptions(options *Options) error {
+	if err := validate(options, t.fields, t.rows); err != nil {
+		return err
+	}
+
+	t.options = options
+
+	return nil
+}
+
+// WithOptions returns a copy of the data table with options applied. Unlike
+// SetOptions, options are applied the same way as in NewWithOptions, so
+// aliased fields are renamed, values are trimmed and defaults are filled in.
+// The data table itself is left unchanged. Returns an error if the copy
+// violates the options.
+func (t *DataTable) WithOptions(options *Options) (*DataTable, error) {
+	return NewWithOptions(options, copyValues(t.fields), copyRows(t.rows)...)
+}
+
 // Fields returns the table fields.
 func (t *DataTable) Fields() []string {
 	return t.fields
 }
 
+// HasField returns true if the data table contains field. Field names are
+// resolved the same way as in Column or GetCell, so aliases and the
+// CaseInsensitiveFields option are taken into account.
+func (t *DataTable) HasField(field string) bool {
+	return t.fieldIndex(field) >= 0
+}
+
+// FieldIndex returns the zero-based column index of field, which can be used
+// to access its values in the rows returned by RowValues. Returns -1 if the
+// data table does not contain the field. Field names are resolved the same way
+// as in HasField.
+func (t *DataTable) FieldIndex(field string) int {
+	return t.fieldIndex(field)
+}
+
 // Rows transforms the data table rows into a slice of maps and returns it.
-// The map keys are the data table's fields for every row.
+// The map keys are the data table's fields for every row. If the
+// AllowDuplicateFields option is set, duplicate fields are suffixed with their
+// occurrence, e.g. "name" and "name_2".
 func (t *DataTable) Rows() []map[string]string {
 	s := make([]map[string]string, len(t.rows))
+	keys := t.mapKeys()
 
 	for i, row := range t.rows {
-		m := make(map[string]string)
-		for j, field := range t.fields {
-			m[field] = row[j]
-		}
-
-		s[i] = m
+		s[i] = mapValues(keys, row)
 	}
 
 	return s
 }
 
+// RowAt returns the row at index as a map of field names to values. Returns
+// an error if index is out of bounds.
+func (t *DataTable) RowAt(index int) (map[string]string, error) {
+	if err := t.checkRowIndex(index); err != nil {
+		return nil, err
+	}
+
+	return t.rowMap(t.rows[index]), nil
+}
+
+// ForEach calls fn for every row of the data table in order. The row is passed
+// to fn as a map of field names to values. Iteration stops as soon as fn
+// returns an error, which is then returned by ForEach.
+func (t *DataTable) ForEach(fn func(index int, row map[string]string) error) error {
+	keys := t.mapKeys()
+
+	for i, row := range t.rows {
+		if err := fn(i, mapValues(keys, row)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RowValues returns the row values.
 func (t *DataTable) RowValues() [][]string {
 	return t.rows
 }
 
+// GetCell returns the value of the cell in the row at rowIndex for given
+// field. Returns an error if the row index is out of bounds or the field does
+// not exist.
+func (t *DataTable) GetCell(rowIndex int, field string) (string, error) {
+	if err := t.checkRowIndex(rowIndex); err != nil {
+		return "", err
+	}
+
+	col, err := t.lookupField(field)
+	if err != nil {
+		return "", err
+	}
+
+	return t.rows[rowIndex][col], nil
+}
+
+// CellLines returns the lines of the cell in the row at rowIndex for given
+// field. The value is split at every newline. Returns an error if the row
+// index is out of bounds or the field does not exist.
+func (t *DataTable) CellLines(rowIndex int, field string) ([]string, error) {
+	value, err := t.GetCell(rowIndex, field)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(value, "\n"), nil
+}
+
+// Column returns the values of all rows for given field. Returns an error if
+// the field does not exist.
+func (t *DataTable) Column(field string) ([]string, error) {
+	col, err := t.lookupField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(t.rows))
+	for i, row := range t.rows {
+		values[i] = row[col]
+	}
+
+	return values, nil
+}
+
+// ColumnMap returns a map of all fields to the values of their column in row
+// order. Duplicate fields are suffixed in the same way as in Rows. This is
+// more efficient than calling Column for every field.
+func (t *DataTable) ColumnMap() map[string][]string {
+	keys := t.mapKeys()
+
+	columns := make([][]string, len(keys))
+	for i := range columns {
+		columns[i] = make([]string, len(t.rows))
+	}
+
+	for i, row := range t.rows {
+		for j, value := range row {
+			columns[j][i] = value
+		}
+	}
+
+	m := make(map[string][]string, len(keys))
+	for i, key := range keys {
+		m[key] = columns[i]
+	}
+
+	return m
+}
+
+// ToKeyValue returns a map of the values of keyField to the values of
+// valueField, which is useful for data tables describing properties. Returns
+// an error if one of the fields does not exist or if keyField contains
+// duplicate values.
+func (t *DataTable) ToKeyValue(keyField, valueField string) (map[string]string, error) {
+	return t.toKeyValue(keyField, valueField, false)
+}
+
+// ToKeyValueAllowDuplicates is like ToKeyValue but tolerates duplicate values
+// in keyField. The value of the last row containing a key wins.
+func (t *DataTable) ToKeyValueAllowDuplicates(keyField, valueField string) (map[string]string, error) {
+	return t.toKeyValue(keyField, valueField, true)
+}
+
+// toKeyValue builds the key value map for ToKeyValue and
+// ToKeyValueAllowDuplicates. If allowDuplicates is false, an error is
+// returned for duplicate keys.
+func (t *DataTable) toKeyValue(keyField, valueField string, allowDuplicates bool) (map[string]string, error) {
+	keyCol, err := t.lookupField(keyField)
+	if err != nil {
+		return nil, err
+	}
+
+	valueCol, err := t.lookupField(valueField)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(t.rows))
+
+	for i, row := range t.rows {
+		key := row[keyCol]
+
+		if _, ok := m[key]; ok && !allowDuplicates {
+			return nil, fmt.Errorf("row %d, field %q: duplicate key %q", i, keyField, key)
+		}
+
+		m[key] = row[valueCol]
+	}
+
+	return m, nil
+}
+
+// fieldIndex returns the column index of field or -1 if the data table does
+// not contain it. Respects the CaseInsensitiveFields and Aliases options.
+func (t *DataTable) fieldIndex(field string) int {
+	return t.options.indexOf(t.fields, t.options.canonical(field))
+}
+
+// lookupField returns the column index of field. Returns an error if the data
+// table does not contain field.
+func (t *DataTable) lookupField(field string) (int, error) {
+	index := t.fieldIndex(field)
+	if index < 0 {
+		return -1, fmt.Errorf("data table does not contain field %q", field)
+	}
+
+	return index, nil
+}
+
+// rowMap returns a map of the data table's fields to the values of row.
+// Duplicate fields are suffixed as described by mapKeys.
+func (t *DataTable) rowMap(row []string) map[string]string {
+	return mapValues(t.mapKeys(), row)
+}
+
+// mapKeys returns the keys used for the fields of the data table in map
+// representations of rows. These are the fields themselves, except for
+// duplicate fields which are suffixed with their occurrence, e.g. "name" and
+// "name_2".
+func (t *DataTable) mapKeys() []string {
+	keys := make([]string, 0, len(t.fields))
+
+	for _, field := range t.fields {
+		keys = append(keys, uniqueFieldName(keys, field))
+	}
+
+	return keys
+}
+
+// checkRowIndex returns an error if index is out of bounds.
+func (t *DataTable) checkRowIndex(index int) error {
+	if index < 0 || index >= len(t.rows) {
+		return fmt.Errorf("row index %d out of range, data table has %d rows", index, len(t.rows))
+	}
+
+	return nil
+}
+
 // PrettyJSON is a convenience function for transforming the data table into
-// its prettyprinted json representation. Will panic if json marshalling fails.
-func (t *DataTable) PrettyJSON() []byte {
-	buf, err := json.Marshal(t.Rows())
+// its prettyprinted json representation.
+func (t *DataTable) PrettyJSON() ([]byte, error) {
+	buf, err := t.MarshalJSON()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return pretty.Pretty(buf)
+	return pretty.Pretty(buf), nil
 }
 
 // rowValues converts a slice of *gherkin.TableRow into a slice of string
@@ -198,6 +931,41 @@ func rowValues(rows []*gherkin.TableRow) [][]string {
 	return vals
 }
 
+// removeValue returns a copy of values without the value at index. The
+// backing array of values is left untouched.
+func removeValue(values []string, index int) []string {
+	result := make([]string, 0, len(values)-1)
+	result = append(result, values[:index]...)
+
+	return append(result, values[index+1:]...)
+}
+
+// mapValues returns a map of keys to the values of row at the same index.
+func mapValues(keys, row []string) map[string]string {
+	m := make(map[string]string, len(keys))
+	for j, key := range keys {
+		m[key] = row[j]
+	}
+
+	return m
+}
+
+// skipCommentRows returns all rows whose first value does not start with
+// prefix.
+func skipCommentRows(rows [][]string, prefix string) [][]string {
+	filtered := make([][]string, 0, len(rows))
+
+	for _, row := range rows {
+		if len(row) > 0 && strings.HasPrefix(row[0], prefix) {
+			continue
+		}
+
+		filtered = append(filtered, row)
+	}
+
+	return filtered
+}
+
 // values converts a *gherkin.TableRow into a slice of strings.
 func values(row *gherkin.TableRow) []string {
 	values := make([]string, len(row.Cells))
@@ -208,8 +976,23 @@ func values(row *gherkin.TableRow) []string {
 	return values
 }
 
-// matchRow returns true if all values in two string slices match pairwise.
+// tableRow converts a slice of strings into a *gherkin.TableRow.
+func tableRow(values []string) *gherkin.TableRow {
+	cells := make([]*gherkin.TableCell, len(values))
+	for i, value := range values {
+		cells[i] = &gherkin.TableCell{Value: value}
+	}
+
+	return &gherkin.TableRow{Cells: cells}
+}
+
+// matchValues returns true if two string slices have the same length and all
+// values match pairwise.
 func matchValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
 	for i := range a {
 		if b[i] != a[i] {
 			return false
@@ -219,6 +1002,48 @@ func matchValues(a, b []string) bool {
 	return true
 }
 
+// matchValuesTrimmed returns true if all values in two string slices match
+// pairwise after trimming leading and trailing whitespace.
+func matchValuesTrimmed(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if strings.TrimSpace(a[i]) != strings.TrimSpace(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchColumns returns true if the values of row at the column indices in
+// cols match values pairwise.
+func matchColumns(row []string, cols []int, values []string) bool {
+	for i, col := range cols {
+		if row[col] != values[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// copyMap returns a copy of m.
+func copyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
 // contains returns true if haystack contains needle
 func contains(haystack []string, needle string) bool {
 	for _, element := range haystack {
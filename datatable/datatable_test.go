@@ -1,6 +1,7 @@
 package datatable
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -25,6 +26,60 @@ func TestFromGherkin(t *testing.T) {
 	}
 }
 
+func TestFromGherkinMultiline(t *testing.T) {
+	dt, err := FromGherkin(buildTable([][]string{
+		{"name", "payload"},
+		{"foo", "{\n  \"a\": 1\n}"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	lines, err := dt.CellLines(0, "payload")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{"{", `  "a": 1`, "}"}
+
+	if !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("expected lines %#v, got %#v", expected, lines)
+	}
+
+	lines, err = dt.CellLines(0, "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(lines, []string{"foo"}) {
+		t.Fatalf("expected lines %#v, got %#v", []string{"foo"}, lines)
+	}
+
+	if _, err := dt.CellLines(1, "payload"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := dt.CellLines(0, "unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestToGherkin(t *testing.T) {
+	fields, rows := testData()
+	table := append([][]string{fields}, rows...)
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := buildTable(table)
+
+	if gt := dt.ToGherkin(); !reflect.DeepEqual(gt, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, gt)
+	}
+}
+
 func TestFromMalformedGherkin(t *testing.T) {
 	_, err := FromGherkin(buildTable([][]string{{"foo"}}))
 	if err == nil {
@@ -32,6 +87,136 @@ func TestFromMalformedGherkin(t *testing.T) {
 	}
 }
 
+func TestFromNilGherkin(t *testing.T) {
+	_, err := FromGherkin(nil)
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err.Error() != "nil gherkin data table" {
+		t.Fatalf("expected error %q, got %q", "nil gherkin data table", err.Error())
+	}
+
+	if _, err := FromGherkinSchema(nil, []string{"foo"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestFromGherkinSkipCommentRows(t *testing.T) {
+	table := buildTable([][]string{
+		{"#name", "value"},
+		{"foo", "1"},
+		{"# bar", "2"},
+		{"// baz", "3"},
+	})
+
+	cases := []struct {
+		name           string
+		options        *Options
+		expectedFields []string
+		expectedRows   [][]string
+	}{
+		{
+			name:           "disabled",
+			expectedFields: []string{"#name", "value"},
+			expectedRows:   [][]string{{"foo", "1"}, {"# bar", "2"}, {"// baz", "3"}},
+		},
+		{
+			name:           "default prefix",
+			options:        &Options{SkipCommentRows: true},
+			expectedFields: []string{"#name", "value"},
+			expectedRows:   [][]string{{"foo", "1"}, {"// baz", "3"}},
+		},
+		{
+			name:           "custom prefix",
+			options:        &Options{SkipCommentRows: true, CommentPrefix: "//"},
+			expectedFields: []string{"#name", "value"},
+			expectedRows:   [][]string{{"foo", "1"}, {"# bar", "2"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := FromGherkinWithOptions(tc.options, table)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(dt.Fields(), tc.expectedFields) {
+				t.Fatalf("expected fields %#v, got %#v", tc.expectedFields, dt.Fields())
+			}
+
+			if !reflect.DeepEqual(dt.RowValues(), tc.expectedRows) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expectedRows, dt.RowValues())
+			}
+		})
+	}
+}
+
+func TestFromGherkinBody(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := FromGherkinBody(fields, buildTable(rows))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(dt.Fields(), fields) {
+		t.Fatalf("expected fields %#v, got %#v", fields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), rows) {
+		t.Fatalf("expected rows %#v, got %#v", rows, dt.RowValues())
+	}
+
+	if _, err := FromGherkinBody([]string{"one", "two"}, buildTable(rows)); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := FromGherkinBody(fields, nil); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestFromGherkinSchema(t *testing.T) {
+	fields, rows := testData()
+	table := buildTable(append([][]string{fields}, rows...))
+
+	dt, err := FromGherkinSchema(table, []string{"one", "two", "three"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), rows) {
+		t.Fatalf("expected rows %#v, got %#v", rows, dt.RowValues())
+	}
+
+	cases := []struct {
+		name   string
+		schema []string
+	}{
+		{name: "wrong order", schema: []string{"two", "one", "three"}},
+		{name: "missing field", schema: []string{"one", "two", "three", "four"}},
+		{name: "additional field", schema: []string{"one", "two"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := FromGherkinSchema(table, tc.schema); err == nil {
+				t.Fatal("expected error but got nil")
+			}
+		})
+	}
+
+	_, err = FromGherkinSchema(table, []string{"two", "one", "three"})
+
+	expected := `data table fields "one", "two", "three" do not match schema "two", "one", "three"`
+
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
 func TestNewWithOptions(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -125,6 +310,194 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+func TestCopyDoesNotShareData(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	ct := dt.Copy()
+
+	if err := ct.UpdateRow(0, []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := ct.MapColumn("two", func(value string) string { return value + "!" }); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := ct.RenameColumn("three", "four"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	ct.Reverse()
+
+	expectedFields, expectedRows := testData()
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+}
+
+func TestCopyOptions(t *testing.T) {
+	fields, rows := testData()
+
+	newOptions := func() *Options {
+		return &Options{
+			OptionalFields: []string{"two", "three"},
+			RequiredFields: []string{"one"},
+			FieldTypes:     map[string]FieldType{"one": Int},
+			Defaults:       map[string]string{"two": "0"},
+			UniqueKeys:     [][]string{{"one", "two"}},
+		}
+	}
+
+	options := newOptions()
+
+	dt, err := NewWithOptions(options, fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	ct := dt.Copy()
+
+	if ct.options == nil {
+		t.Fatal("expected options to be copied")
+	}
+
+	if ct.options == dt.options {
+		t.Fatal("copied options point to source options")
+	}
+
+	options.OptionalFields[0] = "four"
+	options.RequiredFields[0] = "four"
+	options.FieldTypes["one"] = Bool
+	options.Defaults["two"] = "1"
+	options.UniqueKeys[0][0] = "four"
+
+	expected := newOptions()
+
+	if !reflect.DeepEqual(ct.options, expected) {
+		t.Fatalf("expected options %#v, got %#v", expected, ct.options)
+	}
+
+	if err := ct.Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestOptions(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if dt.Options() != nil {
+		t.Fatalf("expected nil options, got %#v", dt.Options())
+	}
+
+	options := &Options{RequiredFields: []string{"one"}}
+
+	if err := dt.SetOptions(options); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	result := dt.Options()
+	if result == options {
+		t.Fatal("expected a copy of the options")
+	}
+
+	if !reflect.DeepEqual(result.RequiredFields, options.RequiredFields) {
+		t.Fatalf("expected required fields %#v, got %#v", options.RequiredFields, result.RequiredFields)
+	}
+
+	result.RequiredFields[0] = "four"
+
+	if dt.options.RequiredFields[0] != "one" {
+		t.Fatal("expected modifying the returned options to not affect the data table")
+	}
+}
+
+func TestSetOptionsInvalid(t *testing.T) {
+	fields, rows := testData()
+
+	options := &Options{RequiredFields: []string{"one"}}
+
+	dt, err := NewWithOptions(options, fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name    string
+		options *Options
+	}{
+		{name: "missing field", options: &Options{RequiredFields: []string{"four"}}},
+		{name: "invalid value", options: &Options{FieldTypes: map[string]FieldType{"one": Bool}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := dt.SetOptions(tc.options); err == nil {
+				t.Fatal("expected error but got nil")
+			}
+
+			if dt.options != options {
+				t.Fatal("expected previous options to be kept")
+			}
+		})
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	dt, err := New(
+		[]string{"user", "role"},
+		[]string{"foo", ""},
+		[]string{"bar", "admin"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	options := &Options{
+		RequiredFields: []string{"name", "role"},
+		Aliases:        map[string]string{"user": "name"},
+		Defaults:       map[string]string{"role": "guest"},
+	}
+
+	result, err := dt.WithOptions(options)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "role"}
+	expectedRows := [][]string{{"foo", "guest"}, {"bar", "admin"}}
+
+	if !reflect.DeepEqual(result.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, result.Fields())
+	}
+
+	if !reflect.DeepEqual(result.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, result.RowValues())
+	}
+
+	if dt.Options() != nil || dt.Fields()[0] != "user" || dt.RowValues()[0][1] != "" {
+		t.Fatal("expected original data table to be left unchanged")
+	}
+
+	if _, err := dt.WithOptions(&Options{RequiredFields: []string{"email"}}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
 func TestRowOperations(t *testing.T) {
 	fields, rows := testData()
 
@@ -138,13 +511,23 @@ func TestRowOperations(t *testing.T) {
 		t.Fatalf("expected index 1, got %d", index)
 	}
 
-	dt.RemoveRow(index)
+	if err := dt.RemoveRow(index); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
 
 	index = dt.FindRow([]string{"4", "5", "6"})
 	if index != -1 {
 		t.Fatalf("expected index -1, got %d", index)
 	}
 
+	if err := dt.RemoveRow(-1); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.RemoveRow(dt.Len()); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
 	err = dt.AppendRow([]string{"10", "11", "12"})
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err.Error())
@@ -156,7 +539,7 @@ func TestRowOperations(t *testing.T) {
 	}
 }
 
-func TestRows(t *testing.T) {
+func TestRemoveRows(t *testing.T) {
 	fields, rows := testData()
 
 	dt, err := New(fields, rows...)
@@ -164,29 +547,853 @@ func TestRows(t *testing.T) {
 		t.Fatalf("unexpected error: %s", err.Error())
 	}
 
-	s := dt.Rows()
+	if err := dt.RemoveRows(0, 3); err == nil {
+		t.Fatal("expected error but got nil")
+	}
 
-	expected := []map[string]string{
-		{"one": "1", "two": "2", "three": "3"},
-		{"one": "4", "two": "5", "three": "6"},
-		{"one": "7", "two": "8", "three": "9"},
+	if dt.Len() != 3 {
+		t.Fatalf("expected 3 rows, got %d", dt.Len())
 	}
 
-	if !reflect.DeepEqual(s, expected) {
-		t.Fatalf("expected %#v, got %#v", expected, s)
+	if err := dt.RemoveRows(0, 2, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"4", "5", "6"}}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
 	}
 }
 
-func testData() ([]string, [][]string) {
-	fields := []string{"one", "two", "three"}
-	rows := [][]string{
-		{"1", "2", "3"},
-		{"4", "5", "6"},
-		{"7", "8", "9"},
+func TestRemoveRowsWhere(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "deleted"},
+		[]string{"foo", "true"},
+		[]string{"bar", "false"},
+		[]string{"baz", "true"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
 	}
 
-	return fields, rows
-}
+	removed := dt.RemoveRowsWhere(func(row map[string]string) bool {
+		return row["deleted"] == "true"
+	})
+
+	if removed != 2 {
+		t.Fatalf("expected 2 removed rows, got %d", removed)
+	}
+
+	expected := [][]string{{"bar", "false"}}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+
+	removed = dt.RemoveRowsWhere(func(row map[string]string) bool {
+		return false
+	})
+
+	if removed != 0 {
+		t.Fatalf("expected 0 removed rows, got %d", removed)
+	}
+}
+
+func TestAppendRows(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.AppendRows(rows[1], rows[2]); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), rows) {
+		t.Fatalf("expected rows %#v, got %#v", rows, dt.RowValues())
+	}
+
+	err = dt.AppendRows([]string{"a", "b", "c"}, []string{"d", "e"})
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	expectedErr := "row 1: expected row length of 3, got 2"
+
+	if err.Error() != expectedErr {
+		t.Fatalf("expected error %q, got %q", expectedErr, err.Error())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), rows) {
+		t.Fatalf("expected rows %#v, got %#v", rows, dt.RowValues())
+	}
+}
+
+func TestFindRows(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, append(rows, []string{"1", "2", "3"})...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	indices := dt.FindRows([]string{"1", "2", "3"})
+	if !reflect.DeepEqual(indices, []int{0, 3}) {
+		t.Fatalf("expected indices %#v, got %#v", []int{0, 3}, indices)
+	}
+
+	indices = dt.FindRows([]string{"10", "11", "12"})
+	if indices == nil || len(indices) != 0 {
+		t.Fatalf("expected empty non-nil slice, got %#v", indices)
+	}
+
+	for _, row := range [][]string{{"1", "2"}, {"1", "2", "3", "4"}} {
+		if indices := dt.FindRows(row); len(indices) != 0 {
+			t.Fatalf("expected no matches for row %#v, got %#v", row, indices)
+		}
+
+		if index := dt.FindRow(row); index != -1 {
+			t.Fatalf("expected index -1 for row %#v, got %d", row, index)
+		}
+	}
+}
+
+func TestFindRowTrimmed(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"foo ", "1"},
+		[]string{"bar", "\t2 "},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		row      []string
+		expected int
+	}{
+		{row: []string{"foo", "1"}, expected: 0},
+		{row: []string{" bar", "2"}, expected: 1},
+		{row: []string{"baz", "3"}, expected: -1},
+		{row: []string{"foo"}, expected: -1},
+	}
+
+	for _, tc := range cases {
+		if index := dt.FindRowTrimmed(tc.row); index != tc.expected {
+			t.Fatalf("expected index %d for row %#v, got %d", tc.expected, tc.row, index)
+		}
+	}
+
+	if index := dt.FindRow([]string{"foo", "1"}); index != -1 {
+		t.Fatalf("expected FindRow to not trim values, got index %d", index)
+	}
+}
+
+func TestFindRowBy(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, append(rows, []string{"1", "5", "3"})...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name        string
+		criteria    map[string]string
+		expected    int
+		expectError bool
+	}{
+		{
+			name:     "single field",
+			criteria: map[string]string{"two": "5"},
+			expected: 1,
+		},
+		{
+			name:     "multiple fields",
+			criteria: map[string]string{"one": "1", "two": "5"},
+			expected: 3,
+		},
+		{
+			name:     "empty criteria",
+			criteria: map[string]string{},
+			expected: 0,
+		},
+		{
+			name:     "no match",
+			criteria: map[string]string{"one": "4", "three": "3"},
+			expected: -1,
+		},
+		{
+			name:        "unknown field",
+			criteria:    map[string]string{"one": "1", "unknown": "2"},
+			expected:    -1,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			index, err := dt.FindRowBy(tc.criteria)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if index != tc.expected {
+				t.Fatalf("expected index %d, got %d", tc.expected, index)
+			}
+		})
+	}
+}
+
+func TestAppendRowMap(t *testing.T) {
+	cases := []struct {
+		name        string
+		row         map[string]string
+		strict      bool
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "all fields",
+			row:      map[string]string{"three": "c", "one": "a", "two": "b"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "missing fields",
+			row:      map[string]string{"two": "b"},
+			expected: []string{"", "b", ""},
+		},
+		{
+			name:     "alias",
+			row:      map[string]string{"first": "a"},
+			expected: []string{"a", "", ""},
+		},
+		{
+			name:        "unknown field",
+			row:         map[string]string{"one": "a", "four": "d"},
+			expectError: true,
+		},
+		{
+			name:        "alias and canonical name",
+			row:         map[string]string{"one": "a", "first": "b"},
+			expectError: true,
+		},
+		{
+			name:     "strict",
+			row:      map[string]string{"three": "c", "one": "a", "two": "b"},
+			strict:   true,
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:        "strict missing fields",
+			row:         map[string]string{"two": "b"},
+			strict:      true,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, rows := testData()
+
+			dt, err := NewWithOptions(&Options{Aliases: map[string]string{"first": "one"}}, fields, rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if tc.strict {
+				err = dt.AppendRowMapStrict(tc.row)
+			} else {
+				err = dt.AppendRowMap(tc.row)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				if dt.Len() != len(rows) {
+					t.Fatalf("expected no row to be appended, got %d rows", dt.Len())
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if row := dt.RowValues()[len(rows)]; !reflect.DeepEqual(row, tc.expected) {
+				t.Fatalf("expected row %#v, got %#v", tc.expected, row)
+			}
+		})
+	}
+}
+
+func TestWithRow(t *testing.T) {
+	dt, err := New([]string{"name", "value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	dt.WithRow("foo", "1").WithRow("bar", "2")
+
+	if err := dt.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"foo", "1"}, {"bar", "2"}}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+
+	dt.WithRow("baz").WithRow("qux", "4")
+
+	if dt.Err() == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+}
+
+func TestUpdateRow(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.UpdateRow(1, []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"1", "2", "3"}, {"a", "b", "c"}, {"7", "8", "9"}}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+
+	if err := dt.UpdateRow(1, []string{"a", "b"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.UpdateRow(3, []string{"a", "b", "c"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestInsertRow(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.InsertRow(1, []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.InsertRow(0, []string{"d", "e", "f"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.InsertRow(dt.Len(), []string{"g", "h", "i"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{
+		{"d", "e", "f"},
+		{"1", "2", "3"},
+		{"a", "b", "c"},
+		{"4", "5", "6"},
+		{"7", "8", "9"},
+		{"g", "h", "i"},
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+
+	if err := dt.InsertRow(0, []string{"a", "b"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.InsertRow(-1, []string{"a", "b", "c"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.InsertRow(dt.Len()+1, []string{"a", "b", "c"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestRemoveColumn(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.RemoveColumn("two"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"one", "three"}
+	expectedRows := [][]string{{"1", "3"}, {"4", "6"}, {"7", "9"}}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+
+	if err := dt.RemoveColumn("two"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestRemoveColumnDoesNotModifyInput(t *testing.T) {
+	fields := []string{"a", "b", "c"}
+	row := []string{"1", "2", "3"}
+
+	dt, err := New(fields, row)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	returnedFields := dt.Fields()
+
+	if err := dt.RemoveColumn("a"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(fields, []string{"a", "b", "c"}) {
+		t.Fatalf("expected input fields to be unchanged, got %#v", fields)
+	}
+
+	if !reflect.DeepEqual(row, []string{"1", "2", "3"}) {
+		t.Fatalf("expected input row to be unchanged, got %#v", row)
+	}
+
+	if !reflect.DeepEqual(returnedFields, []string{"a", "b", "c"}) {
+		t.Fatalf("expected previously returned fields to be unchanged, got %#v", returnedFields)
+	}
+}
+
+func TestRemoveColumnRequiredField(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := NewWithOptions(&Options{RequiredFields: []string{"one", "two"}}, fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.RemoveColumn("two"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if !reflect.DeepEqual(dt.Fields(), fields) {
+		t.Fatalf("expected fields %#v, got %#v", fields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), rows) {
+		t.Fatalf("expected rows %#v, got %#v", rows, dt.RowValues())
+	}
+
+	if err := dt.RemoveColumn("three"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestAppendColumn(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.AppendColumn("four", "x"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"one", "two", "three", "four"}
+	expectedRows := [][]string{{"1", "2", "3", "x"}, {"4", "5", "6", "x"}, {"7", "8", "9", "x"}}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+
+	if err := dt.AppendColumn("four", "y"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestAppendColumnWithOptions(t *testing.T) {
+	options := &Options{
+		RequiredFields: []string{"name"},
+		OptionalFields: []string{"value"},
+	}
+
+	dt, err := NewWithOptions(options, []string{"name"}, []string{"foo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.AppendColumn("unknown", ""); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if !reflect.DeepEqual(dt.Fields(), []string{"name"}) {
+		t.Fatalf("expected fields to be unchanged, got %#v", dt.Fields())
+	}
+
+	if err := dt.AppendColumn("value", "bar"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestRenameColumn(t *testing.T) {
+	cases := []struct {
+		name           string
+		options        *Options
+		oldName        string
+		newName        string
+		expectedFields []string
+		expectError    bool
+	}{
+		{
+			name:           "rename field",
+			oldName:        "two",
+			newName:        "second",
+			expectedFields: []string{"one", "second", "three"},
+		},
+		{
+			name:           "rename to same name",
+			oldName:        "two",
+			newName:        "two",
+			expectedFields: []string{"one", "two", "three"},
+		},
+		{
+			name:        "unknown field",
+			oldName:     "unknown",
+			newName:     "second",
+			expectError: true,
+		},
+		{
+			name:        "new name already exists",
+			oldName:     "two",
+			newName:     "three",
+			expectError: true,
+		},
+		{
+			name:        "rename required field",
+			options:     &Options{RequiredFields: []string{"one", "two"}},
+			oldName:     "two",
+			newName:     "second",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, rows := testData()
+
+			dt, err := NewWithOptions(tc.options, fields, rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			err = dt.RenameColumn(tc.oldName, tc.newName)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(dt.Fields(), tc.expectedFields) {
+				t.Fatalf("expected fields %#v, got %#v", tc.expectedFields, dt.Fields())
+			}
+
+			if !reflect.DeepEqual(dt.RowValues(), rows) {
+				t.Fatalf("expected rows %#v, got %#v", rows, dt.RowValues())
+			}
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	s := dt.Rows()
+
+	expected := []map[string]string{
+		{"one": "1", "two": "2", "three": "3"},
+		{"one": "4", "two": "5", "three": "6"},
+		{"one": "7", "two": "8", "three": "9"},
+	}
+
+	if !reflect.DeepEqual(s, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, s)
+	}
+}
+
+func TestRowAt(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	row, err := dt.RowAt(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := map[string]string{"one": "4", "two": "5", "three": "6"}
+
+	if !reflect.DeepEqual(row, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, row)
+	}
+
+	for _, index := range []int{-1, 3} {
+		if _, err := dt.RowAt(index); err == nil {
+			t.Fatalf("expected error for index %d but got nil", index)
+		}
+	}
+}
+
+func TestForEach(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	visited := make([]map[string]string, 0)
+
+	err = dt.ForEach(func(index int, row map[string]string) error {
+		if index != len(visited) {
+			t.Fatalf("expected index %d, got %d", len(visited), index)
+		}
+
+		visited = append(visited, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(visited, dt.Rows()) {
+		t.Fatalf("expected %#v, got %#v", dt.Rows(), visited)
+	}
+}
+
+func TestForEachStopsOnError(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedErr := errors.New("stop")
+	calls := 0
+
+	err = dt.ForEach(func(index int, row map[string]string) error {
+		calls++
+		if row["one"] == "4" {
+			return expectedErr
+		}
+
+		return nil
+	})
+	if err != expectedErr {
+		t.Fatalf("expected error %v, got %v", expectedErr, err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestGetCell(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	value, err := dt.GetCell(1, "three")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if value != "6" {
+		t.Fatalf("expected value %q, got %q", "6", value)
+	}
+
+	if _, err := dt.GetCell(3, "one"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := dt.GetCell(-1, "one"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := dt.GetCell(0, "unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestColumn(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	values, err := dt.Column("two")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{"2", "5", "8"}
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, values)
+	}
+
+	if _, err := dt.Column("unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestColumnEmpty(t *testing.T) {
+	dt, err := New([]string{"one"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	values, err := dt.Column("one")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if values == nil || len(values) != 0 {
+		t.Fatalf("expected empty non-nil slice, got %#v", values)
+	}
+}
+
+func TestColumnMap(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := map[string][]string{
+		"one":   {"1", "4", "7"},
+		"two":   {"2", "5", "8"},
+		"three": {"3", "6", "9"},
+	}
+
+	if columns := dt.ColumnMap(); !reflect.DeepEqual(columns, expected) {
+		t.Fatalf("expected columns %#v, got %#v", expected, columns)
+	}
+}
+
+func TestToKeyValue(t *testing.T) {
+	dt, err := New(
+		[]string{"key", "value"},
+		[]string{"host", "localhost"},
+		[]string{"port", "8080"},
+		[]string{"port", "9090"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := dt.ToKeyValue("key", "value"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := dt.ToKeyValue("key", "nonexistent"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	m, err := dt.ToKeyValueAllowDuplicates("key", "value")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := map[string]string{"host": "localhost", "port": "9090"}
+
+	if !reflect.DeepEqual(m, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, m)
+	}
+
+	if err := dt.RemoveRow(2); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	m, err = dt.ToKeyValue("key", "value")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected = map[string]string{"host": "localhost", "port": "8080"}
+
+	if !reflect.DeepEqual(m, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, m)
+	}
+}
+
+func testData() ([]string, [][]string) {
+	fields := []string{"one", "two", "three"}
+	rows := [][]string{
+		{"1", "2", "3"},
+		{"4", "5", "6"},
+		{"7", "8", "9"},
+	}
+
+	return fields, rows
+}
 
 func buildTable(src [][]string) *gherkin.DataTable {
 	rows := make([]*gherkin.TableRow, len(src))
@@ -201,3 +1408,116 @@ func buildTable(src [][]string) *gherkin.DataTable {
 
 	return &gherkin.DataTable{Rows: rows}
 }
+
+func TestShape(t *testing.T) {
+	dt, err := New([]string{"one", "two"}, []string{"1", "2"}, []string{"3", "4"}, []string{"5", "6"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if dt.NumFields() != 2 {
+		t.Fatalf("expected 2 fields, got %d", dt.NumFields())
+	}
+
+	rows, cols := dt.Shape()
+	if rows != 3 || cols != 2 {
+		t.Fatalf("expected shape (3, 2), got (%d, %d)", rows, cols)
+	}
+}
+
+func TestHasField(t *testing.T) {
+	options := &Options{
+		CaseInsensitiveFields: true,
+		Aliases:               map[string]string{"id": "one"},
+	}
+
+	cases := []struct {
+		name     string
+		options  *Options
+		field    string
+		expected bool
+	}{
+		{name: "present", field: "one", expected: true},
+		{name: "absent", field: "four", expected: false},
+		{name: "different case", field: "ONE", expected: false},
+		{name: "case-insensitive", options: options, field: "ONE", expected: true},
+		{name: "alias", options: options, field: "id", expected: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, rows := testData()
+
+			dt, err := NewWithOptions(tc.options, fields, rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if result := dt.HasField(tc.field); result != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFieldIndex(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := NewWithOptions(&Options{Aliases: map[string]string{"last": "three"}}, fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		field    string
+		expected int
+	}{
+		{field: "one", expected: 0},
+		{field: "three", expected: 2},
+		{field: "last", expected: 2},
+		{field: "four", expected: -1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.field, func(t *testing.T) {
+			if index := dt.FieldIndex(tc.field); index != tc.expected {
+				t.Fatalf("expected index %d, got %d", tc.expected, index)
+			}
+		})
+	}
+}
+
+func TestEmptyAndClear(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := NewWithOptions(&Options{RequiredFields: []string{"one"}}, fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if dt.Empty() {
+		t.Fatal("expected data table not to be empty")
+	}
+
+	dt.Clear()
+
+	if !dt.Empty() {
+		t.Fatalf("expected data table to be empty, got %d rows", dt.Len())
+	}
+
+	if !reflect.DeepEqual(dt.Fields(), fields) {
+		t.Fatalf("expected fields %#v, got %#v", fields, dt.Fields())
+	}
+
+	if dt.options == nil {
+		t.Fatal("expected options to be kept")
+	}
+
+	if err := dt.AppendRow([]string{"a", "b", "c"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if dt.Len() != 1 {
+		t.Fatalf("expected 1 row, got %d", dt.Len())
+	}
+}
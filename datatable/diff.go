@@ -0,0 +1,335 @@
+package datatable
+
+import (
+	"errors"
+)
+
+// Diff describes the row-level differences between two data tables.
+type Diff struct {
+	// Fields are the fields of both data tables.
+	Fields []string
+
+	// Added contains the rows that are only present in the other data table.
+	Added [][]string
+
+	// Removed contains the rows that are only present in the original data
+	// table.
+	Removed [][]string
+
+	// Changed contains the rows whose values differ between both data
+	// tables.
+	Changed []RowChange
+}
+
+// RowChange describes a row whose values differ between two data tables.
+type RowChange struct {
+	// Index is the index of the row in the original data table.
+	Index int
+
+	// Old contains the row values of the original data table.
+	Old []string
+
+	// New contains the row values of the other data table.
+	New []string
+}
+
+// CellChange describes a single cell whose value differs between two data
+// tables.
+type CellChange struct {
+	// Row is the index of the row in the original data table.
+	Row int
+
+	Field string
+	Old   string
+	New   string
+}
+
+// Diff computes the differences between the data table and other. Rows that
+// are present in both data tables are ignored, regardless of their position.
+// The remaining rows are paired up in order and reported as changed rows. Rows
+// that cannot be paired up are reported as added or removed. Returns an error
+// if the data tables do not have the same fields.
+func (t *DataTable) Diff(other *DataTable) (*Diff, error) {
+	if !matchValues(t.fields, other.fields) {
+		return nil, errors.New("cannot diff data tables with different fields")
+	}
+
+	matched, unmatched := pairRows(t.rows, other.rows)
+
+	d := &Diff{Fields: t.fields}
+
+	for j, otherRow := range other.rows {
+		if matched[j] {
+			continue
+		}
+
+		if len(unmatched) == 0 {
+			d.Added = append(d.Added, otherRow)
+			continue
+		}
+
+		index := unmatched[0]
+		unmatched = unmatched[1:]
+
+		d.Changed = append(d.Changed, RowChange{
+			Index: index,
+			Old:   t.rows[index],
+			New:   otherRow,
+		})
+	}
+
+	for _, index := range unmatched {
+		d.Removed = append(d.Removed, t.rows[index])
+	}
+
+	return d, nil
+}
+
+// Equal returns true if the data table and other have the same fields and the
+// same rows in the same order.
+func (t *DataTable) Equal(other *DataTable) bool {
+	if !matchValues(t.fields, other.fields) || len(t.rows) != len(other.rows) {
+		return false
+	}
+
+	for i, row := range t.rows {
+		if !matchValues(row, other.rows[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CanonicalEqual returns true if the data table and other contain the same
+// fields, regardless of their order, and the same rows in the same order.
+// Rows are compared field by field. This is useful to compare data tables
+// after a round-trip through a serialization format that does not preserve
+// the order of fields.
+func (t *DataTable) CanonicalEqual(other *DataTable) bool {
+	if len(t.fields) != len(other.fields) || len(t.rows) != len(other.rows) {
+		return false
+	}
+
+	cols := make([]int, len(t.fields))
+	for i, field := range t.fields {
+		if cols[i] = (*Options)(nil).indexOf(other.fields, field); cols[i] < 0 {
+			return false
+		}
+	}
+
+	for i, row := range t.rows {
+		if !matchValues(row, columnValues(other.rows[i], cols)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualUnordered returns true if the data table and other have the same fields
+// and the same rows, ignoring the order of the rows. Every row of the data
+// table must match a distinct row in other.
+func (t *DataTable) EqualUnordered(other *DataTable) bool {
+	if !matchValues(t.fields, other.fields) || len(t.rows) != len(other.rows) {
+		return false
+	}
+
+	_, unmatched := pairRows(t.rows, other.rows)
+
+	return len(unmatched) == 0
+}
+
+// FirstDifference compares the data table with other cell by cell and reports
+// the first cell that differs. It returns the row index, the field and the
+// values of the cell in the data table (a) and in other (b). If the data
+// tables are identical, differs is false. If the fields differ, row is -1, a
+// and b are the first differing field names and field is a, or b if the data
+// table has fewer fields. If one of the data tables has more rows, the first
+// field of the first additional row is reported and the value of the data
+// table without that row is empty. Field is empty if the data tables have no
+// fields.
+func (t *DataTable) FirstDifference(other *DataTable) (row int, field string, a string, b string, differs bool) {
+	for i := 0; i < len(t.fields) || i < len(other.fields); i++ {
+		x, y := valueAt(t.fields, i), valueAt(other.fields, i)
+		if x == y {
+			continue
+		}
+
+		if x == "" {
+			return -1, y, x, y, true
+		}
+
+		return -1, x, x, y, true
+	}
+
+	n := len(t.rows)
+	if len(other.rows) < n {
+		n = len(other.rows)
+	}
+
+	for i := 0; i < n; i++ {
+		for j, f := range t.fields {
+			if a, b := t.rows[i][j], other.rows[i][j]; a != b {
+				return i, f, a, b, true
+			}
+		}
+	}
+
+	if len(t.rows) == len(other.rows) {
+		return 0, "", "", "", false
+	}
+
+	if n < len(t.rows) {
+		a = valueAt(t.rows[n], 0)
+	} else {
+		b = valueAt(other.rows[n], 0)
+	}
+
+	return n, valueAt(t.fields, 0), a, b, true
+}
+
+// valueAt returns the value at index i of values or an empty string if i is
+// out of bounds.
+func valueAt(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+
+	return ""
+}
+
+// ContainsRows returns true if every row of expected is present in the data
+// table. Rows are compared on the fields of expected, fields of the data table
+// that expected does not contain are ignored. If expected contains a field
+// that the data table lacks, none of its rows can match. The second return
+// value contains the indices of the rows of expected which could not be
+// found, in ascending order.
+func (t *DataTable) ContainsRows(expected *DataTable) (bool, []int) {
+	cols := make([]int, len(expected.fields))
+	complete := true
+
+	for i, field := range expected.fields {
+		if cols[i] = t.fieldIndex(field); cols[i] < 0 {
+			complete = false
+		}
+	}
+
+	missing := make([]int, 0)
+
+	for i, row := range expected.rows {
+		found := false
+
+		for _, r := range t.rows {
+			if complete && matchColumns(r, cols, row) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, i)
+		}
+	}
+
+	return len(missing) == 0, missing
+}
+
+// Empty returns true if there are no differences.
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// CellChanges returns all cells whose values changed.
+func (d *Diff) CellChanges() []CellChange {
+	changes := make([]CellChange, 0)
+
+	for _, c := range d.Changed {
+		for i, field := range d.Fields {
+			if c.Old[i] != c.New[i] {
+				changes = append(changes, CellChange{
+					Row:   c.Index,
+					Field: field,
+					Old:   c.Old[i],
+					New:   c.New[i],
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// String renders the diff in the pipe-delimited gherkin format. The first
+// column marks removed rows with "-", added rows with "+" and changed rows with
+// "~". Changed cells are rendered as "old -> new".
+func (d *Diff) String() string {
+	return renderGherkin(d.header(), d.rows())
+}
+
+// ToMarkdown renders the diff as GitHub flavored markdown table. See String for
+// a description of the format.
+func (d *Diff) ToMarkdown() string {
+	return renderMarkdown(d.header(), d.rows())
+}
+
+// header returns the diff fields prepended by an empty marker field.
+func (d *Diff) header() []string {
+	return append([]string{""}, d.Fields...)
+}
+
+// rows returns the diff rows prepended by their marker.
+func (d *Diff) rows() [][]string {
+	rows := make([][]string, 0, len(d.Removed)+len(d.Added)+len(d.Changed))
+
+	for _, row := range d.Removed {
+		rows = append(rows, append([]string{"-"}, row...))
+	}
+
+	for _, row := range d.Added {
+		rows = append(rows, append([]string{"+"}, row...))
+	}
+
+	for _, c := range d.Changed {
+		row := make([]string, len(c.Old)+1)
+		row[0] = "~"
+
+		for i := range c.Old {
+			if c.Old[i] == c.New[i] {
+				row[i+1] = c.Old[i]
+			} else {
+				row[i+1] = c.Old[i] + " -> " + c.New[i]
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// pairRows pairs every row in a with a distinct matching row in b. It returns
+// which rows of b were matched and the indices of the rows in a that have no
+// matching row in b.
+func pairRows(a, b [][]string) ([]bool, []int) {
+	matched := make([]bool, len(b))
+	unmatched := make([]int, 0)
+
+	for i, row := range a {
+		found := false
+
+		for j, other := range b {
+			if !matched[j] && matchValues(row, other) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			unmatched = append(unmatched, i)
+		}
+	}
+
+	return matched, unmatched
+}
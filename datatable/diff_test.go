@@ -0,0 +1,423 @@
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	other, err := New(
+		fields,
+		[]string{"7", "8", "9"},
+		[]string{"1", "x", "3"},
+		[]string{"4", "5", "6"},
+		[]string{"10", "11", "12"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	d, err := dt.Diff(other)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if d.Empty() {
+		t.Fatal("expected diff to be non-empty")
+	}
+
+	expected := &Diff{
+		Fields: fields,
+		Added:  [][]string{{"10", "11", "12"}},
+		Changed: []RowChange{
+			{Index: 0, Old: []string{"1", "2", "3"}, New: []string{"1", "x", "3"}},
+		},
+	}
+
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+
+	expectedChanges := []CellChange{{Row: 0, Field: "two", Old: "2", New: "x"}}
+
+	if changes := d.CellChanges(); !reflect.DeepEqual(changes, expectedChanges) {
+		t.Fatalf("expected %#v, got %#v", expectedChanges, changes)
+	}
+
+	reverse, err := other.Diff(dt)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedRemoved := [][]string{{"10", "11", "12"}}
+
+	if !reflect.DeepEqual(reverse.Removed, expectedRemoved) {
+		t.Fatalf("expected %#v, got %#v", expectedRemoved, reverse.Removed)
+	}
+}
+
+func TestDiffEmpty(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	d, err := dt.Diff(dt.Copy())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !d.Empty() {
+		t.Fatalf("expected empty diff, got %#v", d)
+	}
+}
+
+func TestDiffFieldMismatch(t *testing.T) {
+	dt, err := New([]string{"one", "two"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	other, err := New([]string{"two", "one"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := dt.Diff(other); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestDiffString(t *testing.T) {
+	d := &Diff{
+		Fields:  []string{"name", "value"},
+		Added:   [][]string{{"baz", "qux"}},
+		Removed: [][]string{{"foo", "bar"}},
+		Changed: []RowChange{
+			{Index: 1, Old: []string{"a", "b"}, New: []string{"a", "c"}},
+		},
+	}
+
+	expected := `|   | name | value  |
+| - | foo  | bar    |
+| + | baz  | qux    |
+| ~ | a    | b -> c |
+`
+
+	if s := d.String(); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+
+	expected = `|     | name | value  |
+| --- | ---- | ------ |
+| -   | foo  | bar    |
+| +   | baz  | qux    |
+| ~   | a    | b -> c |
+`
+
+	if s := d.ToMarkdown(); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	reordered, err := New(fields, rows[2], rows[0], rows[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	duplicates, err := New(fields, rows[0], rows[0], rows[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	otherFields, err := New([]string{"three", "two", "one"}, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name              string
+		other             *DataTable
+		expected          bool
+		expectedUnordered bool
+	}{
+		{name: "copy", other: dt.Copy(), expected: true, expectedUnordered: true},
+		{name: "reordered rows", other: reordered, expected: false, expectedUnordered: true},
+		{name: "duplicate rows", other: duplicates, expected: false, expectedUnordered: false},
+		{name: "different fields", other: otherFields, expected: false, expectedUnordered: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if equal := dt.Equal(tc.other); equal != tc.expected {
+				t.Fatalf("expected Equal to return %t, got %t", tc.expected, equal)
+			}
+
+			if equal := dt.EqualUnordered(tc.other); equal != tc.expectedUnordered {
+				t.Fatalf("expected EqualUnordered to return %t, got %t", tc.expectedUnordered, equal)
+			}
+		})
+	}
+}
+
+func TestCanonicalEqual(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	reorderedFields, err := New(
+		[]string{"three", "one", "two"},
+		[]string{"3", "1", "2"},
+		[]string{"6", "4", "5"},
+		[]string{"9", "7", "8"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	reorderedRows, err := New(fields, rows[2], rows[0], rows[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	otherFields, err := New([]string{"one", "two", "four"}, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	fewerFields, err := New([]string{"one", "two"}, []string{"1", "2"}, []string{"4", "5"}, []string{"7", "8"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name     string
+		other    *DataTable
+		expected bool
+	}{
+		{name: "copy", other: dt.Copy(), expected: true},
+		{name: "reordered fields", other: reorderedFields, expected: true},
+		{name: "reordered rows", other: reorderedRows, expected: false},
+		{name: "different fields", other: otherFields, expected: false},
+		{name: "fewer fields", other: fewerFields, expected: false},
+		{name: "fewer rows", other: dt.Head(2), expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if equal := dt.CanonicalEqual(tc.other); equal != tc.expected {
+				t.Fatalf("expected CanonicalEqual to return %t, got %t", tc.expected, equal)
+			}
+		})
+	}
+}
+
+func TestContainsRows(t *testing.T) {
+	actual, err := New(
+		[]string{"event", "user", "timestamp"},
+		[]string{"login", "foo", "1"},
+		[]string{"click", "foo", "2"},
+		[]string{"login", "bar", "3"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name            string
+		fields          []string
+		rows            [][]string
+		expected        bool
+		expectedMissing []int
+	}{
+		{
+			name:            "subset of shared fields",
+			fields:          []string{"user", "event"},
+			rows:            [][]string{{"bar", "login"}, {"foo", "click"}},
+			expected:        true,
+			expectedMissing: []int{},
+		},
+		{
+			name:            "missing rows",
+			fields:          []string{"event", "user"},
+			rows:            [][]string{{"logout", "foo"}, {"login", "foo"}, {"click", "bar"}},
+			expected:        false,
+			expectedMissing: []int{0, 2},
+		},
+		{
+			name:            "fields not present in data table never match",
+			fields:          []string{"event", "extra"},
+			rows:            [][]string{{"click", "anything"}, {"login", ""}},
+			expected:        false,
+			expectedMissing: []int{0, 1},
+		},
+		{
+			name:            "no shared fields",
+			fields:          []string{"extra"},
+			rows:            [][]string{{"anything"}},
+			expected:        false,
+			expectedMissing: []int{0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, err := New(tc.fields, tc.rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			ok, missing := actual.ContainsRows(expected)
+			if ok != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, ok)
+			}
+
+			if !reflect.DeepEqual(missing, tc.expectedMissing) {
+				t.Fatalf("expected missing rows %#v, got %#v", tc.expectedMissing, missing)
+			}
+		})
+	}
+}
+
+func TestFirstDifference(t *testing.T) {
+	base := [][]string{{"foo", "ok"}, {"bar", "ok"}}
+
+	cases := []struct {
+		name          string
+		fields        []string
+		rows          [][]string
+		expectedRow   int
+		expectedField string
+		expectedA     string
+		expectedB     string
+		expectDiffers bool
+	}{
+		{
+			name:   "identical",
+			fields: []string{"name", "status"},
+			rows:   [][]string{{"foo", "ok"}, {"bar", "ok"}},
+		},
+		{
+			name:          "changed cell",
+			fields:        []string{"name", "status"},
+			rows:          [][]string{{"foo", "ok"}, {"bar", "fail"}},
+			expectedRow:   1,
+			expectedField: "status",
+			expectedA:     "ok",
+			expectedB:     "fail",
+			expectDiffers: true,
+		},
+		{
+			name:          "different fields",
+			fields:        []string{"name", "state"},
+			rows:          base,
+			expectedRow:   -1,
+			expectedField: "status",
+			expectedA:     "status",
+			expectedB:     "state",
+			expectDiffers: true,
+		},
+		{
+			name:          "additional field",
+			fields:        []string{"name", "status", "extra"},
+			rows:          [][]string{{"foo", "ok", ""}, {"bar", "ok", ""}},
+			expectedRow:   -1,
+			expectedField: "extra",
+			expectedB:     "extra",
+			expectDiffers: true,
+		},
+		{
+			name:          "additional row",
+			fields:        []string{"name", "status"},
+			rows:          [][]string{{"foo", "ok"}, {"bar", "ok"}, {"", ""}},
+			expectedRow:   2,
+			expectedField: "name",
+			expectDiffers: true,
+		},
+		{
+			name:          "missing row",
+			fields:        []string{"name", "status"},
+			rows:          [][]string{{"foo", "ok"}},
+			expectedRow:   1,
+			expectedField: "name",
+			expectedA:     "bar",
+			expectDiffers: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := New([]string{"name", "status"}, base...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			other, err := New(tc.fields, tc.rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			row, field, a, b, differs := dt.FirstDifference(other)
+			if differs != tc.expectDiffers {
+				t.Fatalf("expected differs to be %t, got %t", tc.expectDiffers, differs)
+			}
+
+			if !differs {
+				return
+			}
+
+			if row != tc.expectedRow || field != tc.expectedField || a != tc.expectedA || b != tc.expectedB {
+				t.Fatalf(
+					"expected (%d, %q, %q, %q), got (%d, %q, %q, %q)",
+					tc.expectedRow, tc.expectedField, tc.expectedA, tc.expectedB,
+					row, field, a, b,
+				)
+			}
+		})
+	}
+}
+
+func TestFirstDifferenceNoFields(t *testing.T) {
+	dt, err := New([]string{}, []string{}, []string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	other, err := New([]string{}, []string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if dt.Equal(other) {
+		t.Fatal("expected data tables to differ")
+	}
+
+	row, field, _, _, differs := dt.FirstDifference(other)
+	if !differs {
+		t.Fatal("expected differs to be true")
+	}
+
+	if row != 1 || field != "" {
+		t.Fatalf("expected row 1 and empty field, got row %d and field %q", row, field)
+	}
+}
@@ -0,0 +1,69 @@
+package datatable
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FromFixedWidth creates a new DataTable from fixed-width columnar text read
+// from r. Every line is sliced into columns of given widths, which are
+// measured in characters, and the resulting values are trimmed. The first
+// line is used as the data table fields, all following lines are used as
+// rows. Empty lines are ignored. Returns an error if a line is shorter than
+// the sum of widths or has non-whitespace characters beyond it.
+func FromFixedWidth(r io.Reader, widths []int) (*DataTable, error) {
+	if len(widths) == 0 {
+		return nil, errors.New("at least one column width is required")
+	}
+
+	total := 0
+
+	for _, width := range widths {
+		if width <= 0 {
+			return nil, fmt.Errorf("column widths must be positive, got %d", width)
+		}
+
+		total += width
+	}
+
+	records := make([][]string, 0)
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := []rune(strings.TrimRight(scanner.Text(), "\r"))
+		if len(line) == 0 {
+			continue
+		}
+
+		if len(line) < total {
+			return nil, fmt.Errorf("line %d: expected at least %d characters, got %d", n, total, len(line))
+		}
+
+		if rest := strings.TrimSpace(string(line[total:])); rest != "" {
+			return nil, fmt.Errorf("line %d: unexpected characters %q after last column", n, rest)
+		}
+
+		record := make([]string, len(widths))
+		offset := 0
+
+		for i, width := range widths {
+			record[i] = strings.TrimSpace(string(line[offset : offset+width]))
+			offset += width
+		}
+
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("fixed-width data must contain at least a header line")
+	}
+
+	return New(records[0], records[1:]...)
+}
@@ -0,0 +1,52 @@
+package datatable
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromFixedWidth(t *testing.T) {
+	data := "name  age city     \r\n" +
+		"foo   42  Berlin   \r\n" +
+		"\n" +
+		"bär   7   São Paulo\n"
+
+	dt, err := FromFixedWidth(strings.NewReader(data), []int{6, 4, 9})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "age", "city"}
+	expectedRows := [][]string{{"foo", "42", "Berlin"}, {"bär", "7", "São Paulo"}}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+}
+
+func TestFromFixedWidthErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   string
+		widths []int
+	}{
+		{name: "no widths", data: "name\n"},
+		{name: "invalid width", data: "name\n", widths: []int{4, 0}},
+		{name: "empty", data: "", widths: []int{4}},
+		{name: "short line", data: "name  age\nfoo   4\n", widths: []int{6, 3}},
+		{name: "long line", data: "name  age\nfoo   42 x\n", widths: []int{6, 3}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := FromFixedWidth(strings.NewReader(tc.data), tc.widths); err == nil {
+				t.Fatal("expected error but got nil")
+			}
+		})
+	}
+}
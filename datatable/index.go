@@ -0,0 +1,140 @@
+package datatable
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// rowIndex maps the composite keys built from the values of a set of fields
+// to the index of the first row containing these values.
+type rowIndex struct {
+	fields []string
+	cols   []int
+	keys   map[string]int
+}
+
+// BuildIndex builds an index over the values of given fields which is used by
+// FindRowBy if its criteria reference exactly the indexed fields. This turns
+// repeated lookups in large data tables into constant time operations. The
+// index is rebuilt lazily after the data table was modified. Modifications of
+// the slices returned by RowValues are not tracked and require another call
+// to BuildIndex. Returns an error if any of the fields does not exist.
+func (t *DataTable) BuildIndex(fields ...string) error {
+	if len(fields) == 0 {
+		return errors.New("index requires at least one field")
+	}
+
+	for _, field := range fields {
+		if _, err := t.lookupField(field); err != nil {
+			return err
+		}
+	}
+
+	t.index = &rowIndex{fields: fields}
+
+	t.buildIndex()
+
+	return nil
+}
+
+// buildIndex (re)builds the index. If any of the indexed fields does not exist
+// anymore, the index is dropped.
+func (t *DataTable) buildIndex() {
+	cols := make([]int, len(t.index.fields))
+	for i, field := range t.index.fields {
+		cols[i] = t.fieldIndex(field)
+		if cols[i] < 0 {
+			t.index = nil
+			return
+		}
+	}
+
+	keys := make(map[string]int, len(t.rows))
+
+	for i, row := range t.rows {
+		key := compositeKey(columnValues(row, cols))
+		if _, ok := keys[key]; !ok {
+			keys[key] = i
+		}
+	}
+
+	t.index.cols = cols
+	t.index.keys = keys
+}
+
+// invalidateIndex marks the index as stale so that it is rebuilt on next use.
+func (t *DataTable) invalidateIndex() {
+	if t.index != nil {
+		t.index.keys = nil
+	}
+}
+
+// findIndexed looks up the first row whose values at the column indices in
+// cols match values pairwise using the index. The second return value is
+// false if the index cannot be used for the lookup.
+func (t *DataTable) findIndexed(cols []int, values []string) (int, bool) {
+	if t.index == nil || len(cols) != len(t.index.fields) {
+		return -1, false
+	}
+
+	if t.index.keys == nil {
+		if t.buildIndex(); t.index == nil {
+			return -1, false
+		}
+	}
+
+	keyValues := make([]string, len(t.index.cols))
+
+	for i, col := range t.index.cols {
+		j := indexOfInt(cols, col)
+		if j < 0 {
+			return -1, false
+		}
+
+		keyValues[i] = values[j]
+	}
+
+	if index, ok := t.index.keys[compositeKey(keyValues)]; ok {
+		return index, true
+	}
+
+	return -1, true
+}
+
+// columnValues returns the values of row at the column indices in cols.
+func columnValues(row []string, cols []int) []string {
+	values := make([]string, len(cols))
+	for i, col := range cols {
+		values[i] = row[col]
+	}
+
+	return values
+}
+
+// compositeKey joins values into a single string that can be used as map key.
+// Every value is prefixed with its length, so that different values never
+// produce the same key, regardless of the characters they contain.
+func compositeKey(values []string) string {
+	var sb strings.Builder
+
+	for _, value := range values {
+		sb.WriteString(strconv.Itoa(len(value)))
+		sb.WriteByte(':')
+		sb.WriteString(value)
+	}
+
+	return sb.String()
+}
+
+// indexOfInt returns the index of needle in haystack or -1 if haystack does
+// not contain it.
+func indexOfInt(haystack []int, needle int) int {
+	for i, element := range haystack {
+		if element == needle {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,75 @@
+package datatable
+
+import (
+	"testing"
+)
+
+func TestBuildIndex(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.BuildIndex("unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.BuildIndex(); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.BuildIndex("one", "two"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	assertFindRowBy(t, dt, map[string]string{"two": "5", "one": "4"}, 1)
+	assertFindRowBy(t, dt, map[string]string{"one": "4", "two": "6"}, -1)
+	assertFindRowBy(t, dt, map[string]string{"three": "9"}, 2)
+
+	if err := dt.AppendRow([]string{"10", "11", "12"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if dt.index.keys != nil {
+		t.Fatal("expected index to be invalidated")
+	}
+
+	assertFindRowBy(t, dt, map[string]string{"one": "10", "two": "11"}, 3)
+
+	if err := dt.RemoveRow(0); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	assertFindRowBy(t, dt, map[string]string{"one": "10", "two": "11"}, 2)
+
+	if err := dt.Sort("one"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	assertFindRowBy(t, dt, map[string]string{"one": "10", "two": "11"}, 0)
+
+	if err := dt.RemoveColumn("two"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	assertFindRowBy(t, dt, map[string]string{"one": "10", "three": "12"}, 0)
+
+	if dt.index != nil {
+		t.Fatal("expected index to be dropped after removing an indexed field")
+	}
+}
+
+func assertFindRowBy(t *testing.T, dt *DataTable, criteria map[string]string, expected int) {
+	t.Helper()
+
+	index, err := dt.FindRowBy(criteria)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if index != expected {
+		t.Fatalf("expected index %d for criteria %#v, got %d", expected, criteria, index)
+	}
+}
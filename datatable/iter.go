@@ -0,0 +1,22 @@
+//go:build go1.23
+// +build go1.23
+
+package datatable
+
+import (
+	"iter"
+)
+
+// RowsSeq returns an iterator over the rows of the data table which yields
+// the row index and the row as a map of field names to values. Unlike Rows,
+// the maps are built lazily, so no maps are created for rows which are not
+// visited when the caller stops iterating early.
+func (t *DataTable) RowsSeq() iter.Seq2[int, map[string]string] {
+	return func(yield func(int, map[string]string) bool) {
+		for i, row := range t.rows {
+			if !yield(i, t.rowMap(row)) {
+				return
+			}
+		}
+	}
+}
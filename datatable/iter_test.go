@@ -0,0 +1,54 @@
+//go:build go1.23
+// +build go1.23
+
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRowsSeq(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	visited := make([]map[string]string, 0)
+
+	for i, row := range dt.RowsSeq() {
+		if i != len(visited) {
+			t.Fatalf("expected index %d, got %d", len(visited), i)
+		}
+
+		visited = append(visited, row)
+	}
+
+	if !reflect.DeepEqual(visited, dt.Rows()) {
+		t.Fatalf("expected %#v, got %#v", dt.Rows(), visited)
+	}
+}
+
+func TestRowsSeqBreak(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	calls := 0
+
+	for _, row := range dt.RowsSeq() {
+		calls++
+		if row["one"] == "4" {
+			break
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected 2 iterations, got %d", calls)
+	}
+}
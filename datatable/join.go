@@ -0,0 +1,146 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Join performs an inner join of the data table with other on the key field.
+// The new data table contains a row for every pair of rows of both data tables
+// which share the same key value, ordered by the rows of the receiver first.
+// Its fields are the fields of the receiver followed by the fields of other
+// except for the key. Fields of other that collide with fields of the receiver
+// are suffixed with "_2" (or "_3" and so on if that also collides). The new
+// data table has no options. Returns an error if the key field is missing from
+// either data table.
+func (t *DataTable) Join(other *DataTable, on string) (*DataTable, error) {
+	leftCol, err := t.lookupField(on)
+	if err != nil {
+		return nil, err
+	}
+
+	rightCol, err := other.lookupField(on)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := copyValues(t.fields)
+	rightCols := make([]int, 0, len(other.fields)-1)
+
+	for i, field := range other.fields {
+		if i == rightCol {
+			continue
+		}
+
+		fields = append(fields, uniqueFieldName(fields, field))
+		rightCols = append(rightCols, i)
+	}
+
+	matches := make(map[string][]int, len(other.rows))
+	for i, row := range other.rows {
+		matches[row[rightCol]] = append(matches[row[rightCol]], i)
+	}
+
+	rows := make([][]string, 0)
+
+	for _, row := range t.rows {
+		for _, i := range matches[row[leftCol]] {
+			joined := make([]string, 0, len(fields))
+			joined = append(joined, row...)
+			joined = append(joined, columnValues(other.rows[i], rightCols)...)
+
+			rows = append(rows, joined)
+		}
+	}
+
+	return &DataTable{fields: fields, rows: rows}, nil
+}
+
+// Concat returns a new data table containing the rows of the receiver followed
+// by the rows of other. The new data table has the same fields and options as
+// the receiver, which is left unchanged. Returns an error if both data tables
+// do not have the same fields in the same order.
+func (t *DataTable) Concat(other *DataTable) (*DataTable, error) {
+	if !matchValues(t.fields, other.fields) {
+		return nil, fmt.Errorf(
+			`cannot concat data tables with different fields "%s" and "%s"`,
+			strings.Join(t.fields, `", "`),
+			strings.Join(other.fields, `", "`),
+		)
+	}
+
+	rows := make([][]string, 0, len(t.rows)+len(other.rows))
+	rows = append(rows, copyRows(t.rows)...)
+	rows = append(rows, copyRows(other.rows)...)
+
+	return t.derive(rows), nil
+}
+
+// Merge combines the rows of all tables into a new data table. Its fields are
+// the union of the fields of all tables in order of their first occurrence.
+// Rows are widened to the full set of fields, filling fields that are missing
+// in a table with empty values. Duplicate fields within a table are suffixed
+// in the same way as in Rows, e.g. "name" and "name_2", so that no values are
+// lost. The new data table has no options. Returns an error if no tables are
+// passed or if any of the tables is nil.
+func Merge(tables ...*DataTable) (*DataTable, error) {
+	if len(tables) == 0 {
+		return nil, errors.New("at least one data table is required for merging")
+	}
+
+	keys := make([][]string, len(tables))
+
+	for i, table := range tables {
+		if table == nil {
+			return nil, fmt.Errorf("data table %d is nil", i)
+		}
+
+		keys[i] = table.mapKeys()
+	}
+
+	fields := make([]string, 0)
+	indices := make(map[string]int)
+
+	for _, tableKeys := range keys {
+		for _, field := range tableKeys {
+			if _, ok := indices[field]; !ok {
+				indices[field] = len(fields)
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	rows := make([][]string, 0)
+
+	for i, table := range tables {
+		cols := make([]int, len(keys[i]))
+		for j, field := range keys[i] {
+			cols[j] = indices[field]
+		}
+
+		for _, row := range table.rows {
+			merged := make([]string, len(fields))
+			for i, col := range cols {
+				merged[col] = row[i]
+			}
+
+			rows = append(rows, merged)
+		}
+	}
+
+	return &DataTable{fields: fields, rows: rows}, nil
+}
+
+// uniqueFieldName returns field if it is not contained in fields. Otherwise a
+// numeric suffix starting at 2 is appended until the name is unique.
+func uniqueFieldName(fields []string, field string) string {
+	name := field
+
+	for n := 2; contains(fields, name); n++ {
+		name = field + "_" + strconv.Itoa(n)
+	}
+
+	return name
+}
@@ -0,0 +1,204 @@
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	users, err := New(
+		[]string{"id", "name"},
+		[]string{"1", "foo"},
+		[]string{"2", "bar"},
+		[]string{"3", "baz"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	orders, err := New(
+		[]string{"order", "id", "name"},
+		[]string{"a", "2", "book"},
+		[]string{"b", "1", "pen"},
+		[]string{"c", "2", "cup"},
+		[]string{"d", "4", "hat"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	joined, err := users.Join(orders, "id")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"id", "name", "order", "name_2"}
+	expectedRows := [][]string{
+		{"1", "foo", "b", "pen"},
+		{"2", "bar", "a", "book"},
+		{"2", "bar", "c", "cup"},
+	}
+
+	if !reflect.DeepEqual(joined.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, joined.Fields())
+	}
+
+	if !reflect.DeepEqual(joined.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, joined.RowValues())
+	}
+}
+
+func TestJoinMissingKey(t *testing.T) {
+	a, err := New([]string{"id", "name"}, []string{"1", "foo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	b, err := New([]string{"key", "value"}, []string{"1", "bar"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := a.Join(b, "id"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := a.Join(b, "key"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestUniqueFieldName(t *testing.T) {
+	fields := []string{"name", "name_2"}
+
+	if name := uniqueFieldName(fields, "value"); name != "value" {
+		t.Fatalf("expected %q, got %q", "value", name)
+	}
+
+	if name := uniqueFieldName(fields, "name"); name != "name_3" {
+		t.Fatalf("expected %q, got %q", "name_3", name)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	options := &Options{RequiredFields: []string{"name"}}
+
+	a, err := NewWithOptions(options, []string{"name", "value"}, []string{"foo", "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	b, err := New([]string{"name", "value"}, []string{"bar", "2"}, []string{"baz", "3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	result, err := a.Concat(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"foo", "1"}, {"bar", "2"}, {"baz", "3"}}
+
+	if !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	if result.options != options {
+		t.Fatal("expected options to be carried over")
+	}
+
+	if a.Len() != 1 {
+		t.Fatalf("expected receiver to be unchanged, got %d rows", a.Len())
+	}
+}
+
+func TestConcatDifferentFields(t *testing.T) {
+	a, err := New([]string{"name", "value"}, []string{"foo", "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	b, err := New([]string{"value", "name"}, []string{"2", "bar"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := a.Concat(b); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a, err := New([]string{"event", "user"}, []string{"login", "foo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	b, err := New([]string{"event", "amount"}, []string{"purchase", "42"}, []string{"refund", "10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	c, err := New([]string{"user", "event"}, []string{"bar", "logout"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	merged, err := Merge(a, b, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"event", "user", "amount"}
+	expectedRows := [][]string{
+		{"login", "foo", ""},
+		{"purchase", "", "42"},
+		{"refund", "", "10"},
+		{"logout", "bar", ""},
+	}
+
+	if !reflect.DeepEqual(merged.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, merged.Fields())
+	}
+
+	if !reflect.DeepEqual(merged.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, merged.RowValues())
+	}
+
+	if _, err := Merge(); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := Merge(a, nil); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestMergeDuplicateFields(t *testing.T) {
+	a, err := NewWithOptions(&Options{AllowDuplicateFields: true}, []string{"a", "a"}, []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	b, err := New([]string{"a"}, []string{"3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	merged, err := Merge(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"a", "a_2"}
+	expectedRows := [][]string{{"1", "2"}, {"3", ""}}
+
+	if !reflect.DeepEqual(merged.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, merged.Fields())
+	}
+
+	if !reflect.DeepEqual(merged.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, merged.RowValues())
+	}
+}
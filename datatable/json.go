@@ -0,0 +1,139 @@
+package datatable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// FromJSON creates a new DataTable from a json array of flat objects. The data
+// table fields are the union of all object keys in the order they are first
+// encountered. Missing keys are filled with empty strings. Numbers and bools
+// are converted to their string representation, null values become empty
+// strings. Returns an error if the data is not an array of objects, if any
+// value is a nested object or array or if the array is followed by other data.
+func FromJSON(data []byte) (*DataTable, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0)
+	objects := make([]map[string]string, 0)
+
+	for dec.More() {
+		obj, keys, err := decodeObject(dec, len(objects))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if !contains(fields, key) {
+				fields = append(fields, key)
+			}
+		}
+
+		objects = append(objects, obj)
+	}
+
+	if err := expectDelim(dec, ']'); err != nil {
+		return nil, err
+	}
+
+	if tok, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("unexpected %v after json array", tok)
+	}
+
+	rows := make([][]string, len(objects))
+	for i, obj := range objects {
+		row := make([]string, len(fields))
+		for j, field := range fields {
+			row[j] = obj[field]
+		}
+
+		rows[i] = row
+	}
+
+	return New(fields, rows...)
+}
+
+// MarshalJSON implements json.Marshaler. The data table is marshalled into an
+// array of objects, one per row, whose keys are the data table's fields.
+func (t *DataTable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Rows())
+}
+
+// decodeObject decodes the next flat json object from dec. It returns the
+// object values converted to strings and the object keys in order.
+func decodeObject(dec *json.Decoder, index int) (map[string]string, []string, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, nil, fmt.Errorf("element %d: %s", index, err.Error())
+	}
+
+	obj := make(map[string]string)
+	keys := make([]string, 0)
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key := tok.(string)
+
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var value string
+
+		switch v := tok.(type) {
+		case string:
+			value = v
+		case json.Number:
+			value = v.String()
+		case bool:
+			value = strconv.FormatBool(v)
+		case nil:
+			value = ""
+		default:
+			return nil, nil, fmt.Errorf("element %d, field %q: nested objects and arrays are not supported", index, key)
+		}
+
+		if _, ok := obj[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		obj[key] = value
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, nil, err
+	}
+
+	return obj, keys, nil
+}
+
+// expectDelim reads the next token from dec and returns an error if it is not
+// the delimiter delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %q, got %v", delim, tok)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package datatable
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	dt, err := New([]string{"name", "value"}, []string{"foo", "bar"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	buf, err := json.Marshal(map[string]interface{}{"table": dt})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `{"table":[{"name":"foo","value":"bar"}]}`
+
+	if string(buf) != expected {
+		t.Fatalf("expected %s, got %s", expected, string(buf))
+	}
+}
+
+func TestPrettyJSON(t *testing.T) {
+	dt, err := New([]string{"name", "value"}, []string{"foo", "bar"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	buf, err := dt.PrettyJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var compact bytes.Buffer
+
+	if err := json.Compact(&compact, buf); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `[{"name":"foo","value":"bar"}]`
+
+	if compact.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, compact.String())
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	data := []byte(`[
+		{"name": "foo", "count": 1, "enabled": true},
+		{"name": "bar", "ratio": 0.5, "enabled": null},
+		{}
+	]`)
+
+	dt, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "count", "enabled", "ratio"}
+	expectedRows := [][]string{
+		{"foo", "1", "true", ""},
+		{"bar", "", "", "0.5"},
+		{"", "", "", ""},
+	}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+}
+
+func TestFromJSONErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name:        "nested object",
+			data:        `[{"name": "foo"}, {"name": {"first": "bar"}}]`,
+			expectedErr: `element 1, field "name": nested objects and arrays are not supported`,
+		},
+		{
+			name:        "nested array",
+			data:        `[{"name": ["foo"]}]`,
+			expectedErr: `element 0, field "name": nested objects and arrays are not supported`,
+		},
+		{
+			name:        "trailing data",
+			data:        `[{"a": "1"}] {"b": 2}`,
+			expectedErr: `unexpected { after json array`,
+		},
+		{
+			name: "trailing garbage",
+			data: `[{"a": "1"}] x`,
+		},
+		{
+			name:        "not an array",
+			data:        `{"name": "foo"}`,
+			expectedErr: `expected "[", got {`,
+		},
+		{
+			name:        "not an object",
+			data:        `["foo"]`,
+			expectedErr: `element 0: expected "{", got foo`,
+		},
+		{
+			name: "malformed",
+			data: `[{"name": "foo"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := FromJSON([]byte(tc.data))
+			if err == nil {
+				t.Fatal("expected error but got nil")
+			}
+
+			if tc.expectedErr != "" && err.Error() != tc.expectedErr {
+				t.Fatalf("expected error %q, got %q", tc.expectedErr, err.Error())
+			}
+		})
+	}
+}
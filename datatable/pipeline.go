@@ -0,0 +1,62 @@
+package datatable
+
+// Pipeline chains transformations of a data table. Once a step fails, all
+// subsequent steps are skipped and the error is reported by Result. The data
+// table the pipeline was created from is never modified.
+type Pipeline struct {
+	table *DataTable
+	err   error
+}
+
+// Pipe creates a new *Pipeline that starts with a copy of the data table.
+func (t *DataTable) Pipe() *Pipeline {
+	return &Pipeline{table: t.derive(copyRows(t.rows))}
+}
+
+// Filter keeps only the rows for which predicate returns true. See
+// (*DataTable).Filter.
+func (p *Pipeline) Filter(predicate func(row map[string]string) bool) *Pipeline {
+	if p.err == nil {
+		p.table = p.table.Filter(predicate)
+	}
+
+	return p
+}
+
+// Select keeps only the given fields. See (*DataTable).Select.
+func (p *Pipeline) Select(fields ...string) *Pipeline {
+	if p.err == nil {
+		p.table, p.err = p.table.Select(fields...)
+	}
+
+	return p
+}
+
+// Sort sorts the rows by the values of given fields. See (*DataTable).Sort.
+func (p *Pipeline) Sort(fields ...string) *Pipeline {
+	if p.err == nil {
+		p.err = p.table.Sort(fields...)
+	}
+
+	return p
+}
+
+// Map replaces the value of each cell by the result of fn. See
+// (*DataTable).Map.
+func (p *Pipeline) Map(fn func(field, value string) string) *Pipeline {
+	if p.err == nil {
+		p.table = p.table.Map(fn)
+	}
+
+	return p
+}
+
+// Result returns the transformed data table or the first error that was
+// encountered by any of the pipeline steps.
+func (p *Pipeline) Result() (*DataTable, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return p.table, nil
+}
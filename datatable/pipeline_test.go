@@ -0,0 +1,106 @@
+package datatable
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "status", "age"},
+		[]string{"foo", "active", "30"},
+		[]string{"bar", "inactive", "25"},
+		[]string{"baz", "active", "20"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	original := dt.RowValues()
+
+	result, err := dt.Pipe().
+		Filter(func(row map[string]string) bool {
+			return row["status"] == "active"
+		}).
+		Select("name", "age").
+		Sort("age").
+		Map(func(field, value string) string {
+			return strings.ToUpper(value)
+		}).
+		Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"BAZ", "20"}, {"FOO", "30"}}
+
+	if !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), original) {
+		t.Fatalf("expected original rows %#v, got %#v", original, dt.RowValues())
+	}
+}
+
+func TestPipelineDoesNotModifySource(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	result, err := dt.Pipe().Sort("one").Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	result.Reverse()
+
+	if err := result.UpdateRow(0, []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := result.MapColumn("two", strings.ToUpper); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	_, expected := testData()
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected original rows %#v, got %#v", expected, dt.RowValues())
+	}
+}
+
+func TestPipelineError(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	called := false
+
+	_, err = dt.Pipe().
+		Select("nonexistent").
+		Sort("one").
+		Map(func(field, value string) string {
+			called = true
+			return value
+		}).
+		Result()
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if called {
+		t.Fatal("expected steps after the error to be skipped")
+	}
+
+	if _, err := dt.Pipe().Sort("nonexistent").Result(); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
@@ -0,0 +1,313 @@
+package datatable
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// gherkinEscaper escapes cell values the same way the gherkin parser expects
+// them.
+var gherkinEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", `\n`)
+
+// escapeGherkin escapes s like gherkinEscaper and additionally escapes leading
+// and trailing whitespace, which would otherwise be lost because cell values
+// are trimmed when parsed.
+func escapeGherkin(s string) string {
+	s = gherkinEscaper.Replace(s)
+
+	trimmed := strings.TrimLeftFunc(s, unicode.IsSpace)
+	leading := s[:len(s)-len(trimmed)]
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	trailing := s[len(leading)+len(trimmed):]
+
+	if leading == "" && trailing == "" {
+		return s
+	}
+
+	return escapeWhitespace(leading) + trimmed + escapeWhitespace(trailing)
+}
+
+// escapeWhitespace escapes every whitespace character in s. Spaces are
+// escaped as `\s`, common control characters like tabs use their usual escape
+// sequence and all other whitespace characters are escaped as `\uXXXX`.
+func escapeWhitespace(s string) string {
+	var sb strings.Builder
+
+	for _, r := range s {
+		switch r {
+		case ' ':
+			sb.WriteString(`\s`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\v':
+			sb.WriteString(`\v`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		}
+	}
+
+	return sb.String()
+}
+
+// String renders the data table in the pipe-delimited gherkin format. Cells are
+// padded so that the pipes of all rows line up. Backslashes, pipes and
+// newlines in cell values are escaped. Leading and trailing whitespace of cell
+// values is escaped as well, e.g. a leading space as `\s` and a trailing tab as
+// `\t`, so that it is preserved by UnmarshalText.
+func (t *DataTable) String() string {
+	return renderGherkin(t.fields, t.rows)
+}
+
+// ToMarkdown renders the data table as GitHub flavored markdown table. Cells
+// are padded so that columns align. Pipe characters in cell values are
+// escaped. Since markdown table cells cannot span multiple lines, only the
+// first line of multiline values is rendered, followed by the number of
+// omitted lines, e.g. "{ [+2 lines]".
+func (t *DataTable) ToMarkdown() string {
+	return renderMarkdown(t.fields, t.rows)
+}
+
+// Alignment defines the horizontal alignment of cell values.
+type Alignment int
+
+// Supported alignments.
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+)
+
+// RenderOptions controls how Render formats a data table.
+type RenderOptions struct {
+	// Alignments maps field names to the alignment of their column. Columns
+	// of fields that are not listed are left-aligned.
+	Alignments map[string]Alignment
+
+	// MaxWidth limits the width of every cell. Longer cell values are
+	// truncated and end with an ellipsis. Values are truncated before they
+	// are escaped, so escape sequences are never cut in half. Zero means no
+	// limit.
+	MaxWidth int
+}
+
+// Render renders the data table in the pipe-delimited gherkin format like
+// String, but allows to control the alignment and the maximum width of
+// columns via opts.
+func (t *DataTable) Render(opts RenderOptions) string {
+	escape := func(s string) string {
+		return gherkinEscaper.Replace(truncate(s, opts.MaxWidth))
+	}
+
+	escaped := make([][]string, len(t.rows)+1)
+
+	escaped[0] = escapeCells(t.fields, escape)
+	for i, row := range t.rows {
+		escaped[i+1] = escapeCells(row, escape)
+	}
+
+	aligns := make([]Alignment, len(t.fields))
+	for field, align := range opts.Alignments {
+		if col := t.fieldIndex(field); col >= 0 {
+			aligns[col] = align
+		}
+	}
+
+	widths := columnWidths(0, escaped)
+
+	var sb strings.Builder
+
+	for _, row := range escaped {
+		writeAlignedRow(&sb, row, widths, aligns)
+	}
+
+	return sb.String()
+}
+
+// ToHTML renders the data table as HTML table. The fields are rendered into
+// the table head and the rows into the table body. All values are HTML
+// escaped.
+func (t *DataTable) ToHTML() string {
+	return t.ToHTMLWithClass("")
+}
+
+// ToHTMLWithClass is like ToHTML but sets the class attribute of the table
+// element to class if it is not empty.
+func (t *DataTable) ToHTMLWithClass(class string) string {
+	var sb strings.Builder
+
+	if class != "" {
+		sb.WriteString(`<table class="` + html.EscapeString(class) + `">` + "\n")
+	} else {
+		sb.WriteString("<table>\n")
+	}
+
+	sb.WriteString("<thead>\n")
+	writeHTMLRow(&sb, "th", t.fields)
+	sb.WriteString("</thead>\n<tbody>\n")
+
+	for _, row := range t.rows {
+		writeHTMLRow(&sb, "td", row)
+	}
+
+	sb.WriteString("</tbody>\n</table>\n")
+
+	return sb.String()
+}
+
+// renderGherkin renders header and rows in the pipe-delimited gherkin format.
+func renderGherkin(header []string, rows [][]string) string {
+	escaped := make([][]string, len(rows)+1)
+
+	escaped[0] = escapeCells(header, escapeGherkin)
+	for i, row := range rows {
+		escaped[i+1] = escapeCells(row, escapeGherkin)
+	}
+
+	widths := columnWidths(0, escaped)
+
+	var sb strings.Builder
+
+	for _, row := range escaped {
+		writeRow(&sb, row, widths)
+	}
+
+	return sb.String()
+}
+
+// renderMarkdown renders header and rows as GitHub flavored markdown table.
+func renderMarkdown(header []string, rows [][]string) string {
+	escaped := make([][]string, len(rows)+1)
+
+	escaped[0] = escapeCells(header, escapeMarkdown)
+	for i, row := range rows {
+		escaped[i+1] = escapeCells(row, escapeMarkdown)
+	}
+
+	widths := columnWidths(3, escaped)
+
+	separator := make([]string, len(widths))
+	for i, width := range widths {
+		separator[i] = strings.Repeat("-", width)
+	}
+
+	var sb strings.Builder
+
+	writeRow(&sb, escaped[0], widths)
+	writeRow(&sb, separator, widths)
+
+	for _, row := range escaped[1:] {
+		writeRow(&sb, row, widths)
+	}
+
+	return sb.String()
+}
+
+// escapeMarkdown escapes pipe characters in s. Multiline values are reduced
+// to their first line followed by an indicator of the number of omitted lines.
+func escapeMarkdown(s string) string {
+	if lines := strings.Split(s, "\n"); len(lines) > 1 {
+		s = fmt.Sprintf("%s [+%d lines]", lines[0], len(lines)-1)
+	}
+
+	return strings.Replace(s, "|", `\|`, -1)
+}
+
+// escapeCells applies escape to all cells and returns the result.
+func escapeCells(cells []string, escape func(string) string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = escape(cell)
+	}
+
+	return escaped
+}
+
+// columnWidths computes the maximum width of each column in rows. Every width
+// is at least minWidth.
+func columnWidths(minWidth int, rows [][]string) []int {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	widths := make([]int, len(rows[0]))
+	for i := range widths {
+		widths[i] = minWidth
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if width := utf8.RuneCountInString(cell); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	return widths
+}
+
+// writeRow writes a pipe-delimited row to sb and pads cells to the given
+// widths.
+func writeRow(sb *strings.Builder, cells []string, widths []int) {
+	writeAlignedRow(sb, cells, widths, nil)
+}
+
+// writeAlignedRow writes a pipe-delimited row to sb and pads cells to the
+// given widths according to aligns. Cells without alignment are left-aligned.
+func writeAlignedRow(sb *strings.Builder, cells []string, widths []int, aligns []Alignment) {
+	sb.WriteString("|")
+
+	for i, cell := range cells {
+		padding := widths[i] - utf8.RuneCountInString(cell)
+		left := 0
+
+		if i < len(aligns) {
+			switch aligns[i] {
+			case AlignRight:
+				left = padding
+			case AlignCenter:
+				left = padding / 2
+			}
+		}
+
+		sb.WriteString(" ")
+		sb.WriteString(strings.Repeat(" ", left))
+		sb.WriteString(cell)
+		sb.WriteString(strings.Repeat(" ", padding-left))
+		sb.WriteString(" |")
+	}
+
+	sb.WriteString("\n")
+}
+
+// writeHTMLRow writes a table row to sb and wraps every HTML escaped cell
+// into an element of given tag.
+func writeHTMLRow(sb *strings.Builder, tag string, cells []string) {
+	sb.WriteString("<tr>")
+
+	for _, cell := range cells {
+		sb.WriteString("<" + tag + ">")
+		sb.WriteString(html.EscapeString(cell))
+		sb.WriteString("</" + tag + ">")
+	}
+
+	sb.WriteString("</tr>\n")
+}
+
+// truncate shortens s to at most maxWidth runes. Truncated values end with an
+// ellipsis. A maxWidth of zero or less disables truncation.
+func truncate(s string, maxWidth int) string {
+	if maxWidth <= 0 || utf8.RuneCountInString(s) <= maxWidth {
+		return s
+	}
+
+	runes := []rune(s)
+
+	return string(runes[:maxWidth-1]) + "…"
+}
@@ -0,0 +1,197 @@
+package datatable
+
+import (
+	"testing"
+)
+
+func TestToMarkdown(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"foo", "a|b"},
+		[]string{"ü", "bar baz qux"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `| name | value       |
+| ---- | ----------- |
+| foo  | a\|b        |
+| ü    | bar baz qux |
+`
+
+	if s := dt.ToMarkdown(); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestString(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"foo", "a|b"},
+		[]string{"ü", "multi\nline"},
+		[]string{`back\slash`, ""},
+		[]string{" x ", "b\t"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `| name        | value       |
+| foo         | a\|b        |
+| ü           | multi\nline |
+| back\\slash |             |
+| \sx\s       | b\t         |
+`
+
+	if s := dt.String(); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestStringEmpty(t *testing.T) {
+	dt, err := New([]string{"name", "value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := "| name | value |\n"
+
+	if s := dt.String(); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestRender(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "amount", "tag"},
+		[]string{"foo", "1", "x"},
+		[]string{"a very long name", "1000", "abc"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name     string
+		opts     RenderOptions
+		expected string
+	}{
+		{
+			name: "defaults",
+			expected: `| name             | amount | tag |
+| foo              | 1      | x   |
+| a very long name | 1000   | abc |
+`,
+		},
+		{
+			name: "alignment",
+			opts: RenderOptions{
+				Alignments: map[string]Alignment{"amount": AlignRight, "tag": AlignCenter, "unknown": AlignRight},
+			},
+			expected: `| name             | amount | tag |
+| foo              |      1 |  x  |
+| a very long name |   1000 | abc |
+`,
+		},
+		{
+			name: "max width",
+			opts: RenderOptions{MaxWidth: 5},
+			expected: `| name  | amou… | tag |
+| foo   | 1     | x   |
+| a ve… | 1000  | abc |
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := dt.Render(tc.opts); s != tc.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%s", tc.expected, s)
+			}
+		})
+	}
+}
+
+func TestRenderTruncatesBeforeEscaping(t *testing.T) {
+	dt, err := New([]string{"v"}, []string{"a|bc"}, []string{`\\xy`})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `| v     |
+| a\|…  |
+| \\\\… |
+`
+
+	if s := dt.Render(RenderOptions{MaxWidth: 3}); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestToHTML(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "<value>"},
+		[]string{"foo", `<script>alert("x")</script>`},
+		[]string{"bar & baz", ""},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `<table>
+<thead>
+<tr><th>name</th><th>&lt;value&gt;</th></tr>
+</thead>
+<tbody>
+<tr><td>foo</td><td>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</td></tr>
+<tr><td>bar &amp; baz</td><td></td></tr>
+</tbody>
+</table>
+`
+
+	if s := dt.ToHTML(); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestToHTMLWithClass(t *testing.T) {
+	dt, err := New([]string{"name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `<table class="report &#34;x&#34;">
+<thead>
+<tr><th>name</th></tr>
+</thead>
+<tbody>
+</tbody>
+</table>
+`
+
+	if s := dt.ToHTMLWithClass(`report "x"`); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
+
+func TestToMarkdownMultiline(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "payload"},
+		[]string{"foo", "{\n  \"a\": 1\n}"},
+		[]string{"bar", "single"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `| name | payload      |
+| ---- | ------------ |
+| foo  | { [+2 lines] |
+| bar  | single       |
+`
+
+	if s := dt.ToMarkdown(); s != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, s)
+	}
+}
@@ -0,0 +1,137 @@
+package datatable
+
+import (
+	"bytes"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// roundTripData returns a data table containing cell values with characters
+// that need special treatment in the supported serialization formats.
+func roundTripData(t *testing.T) *DataTable {
+	dt, err := New(
+		[]string{"name", "with space", "pipe|field", "ünïcode"},
+		[]string{"plain", "", "a|b", "日本語"},
+		[]string{"multi\nline", "comma, separated", `"quoted"`, "tab\tseparated"},
+		[]string{`back\slash`, `\n literal`, "'single'", "emoji 🎉"},
+		[]string{"true", "42", "null", "- dash"},
+		[]string{" padded ", "\t", "trailing\u00a0", `\s literal `},
+		[]string{"", "", "", ""},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return dt
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := []struct {
+		name      string
+		roundTrip func(dt *DataTable) (*DataTable, error)
+	}{
+		{
+			name: "gherkin",
+			roundTrip: func(dt *DataTable) (*DataTable, error) {
+				return FromGherkin(dt.ToGherkin())
+			},
+		},
+		{
+			name: "text",
+			roundTrip: func(dt *DataTable) (*DataTable, error) {
+				data, err := dt.MarshalText()
+				if err != nil {
+					return nil, err
+				}
+
+				var result DataTable
+
+				return &result, result.UnmarshalText(data)
+			},
+		},
+		{
+			name: "csv",
+			roundTrip: func(dt *DataTable) (*DataTable, error) {
+				data, err := dt.ToCSV()
+				if err != nil {
+					return nil, err
+				}
+
+				return FromCSV(bytes.NewReader(data))
+			},
+		},
+		{
+			name: "tsv",
+			roundTrip: func(dt *DataTable) (*DataTable, error) {
+				data, err := dt.ToTSV()
+				if err != nil {
+					return nil, err
+				}
+
+				return FromTSV(bytes.NewReader(data))
+			},
+		},
+		{
+			name: "json",
+			roundTrip: func(dt *DataTable) (*DataTable, error) {
+				data, err := dt.MarshalJSON()
+				if err != nil {
+					return nil, err
+				}
+
+				return FromJSON(data)
+			},
+		},
+		{
+			name: "yaml",
+			roundTrip: func(dt *DataTable) (*DataTable, error) {
+				data, err := dt.ToYAML()
+				if err != nil {
+					return nil, err
+				}
+
+				var items []yaml.MapSlice
+
+				if err := yaml.Unmarshal(data, &items); err != nil {
+					return nil, err
+				}
+
+				fields := make([]string, 0)
+				for _, item := range items[0] {
+					fields = append(fields, item.Key.(string))
+				}
+
+				rows := make([][]string, len(items))
+				for i, item := range items {
+					rows[i] = make([]string, len(item))
+					for j, value := range item {
+						rows[i][j] = value.Value.(string)
+					}
+				}
+
+				return New(fields, rows...)
+			},
+		},
+	}
+
+	single, err := New([]string{"value"}, []string{""}, []string{"x"}, []string{""})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, dt := range []*DataTable{roundTripData(t), single} {
+				result, err := tc.roundTrip(dt)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+
+				if !dt.CanonicalEqual(result) {
+					t.Fatalf("expected round-trip to preserve data table:\n%#v\ngot:\n%#v", dt.RowValues(), result.RowValues())
+				}
+			}
+		})
+	}
+}
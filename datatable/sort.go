@@ -0,0 +1,85 @@
+package datatable
+
+import (
+	"sort"
+)
+
+// Sort performs a stable sort of the data table rows using the values of
+// given fields as sort keys. Values are compared as strings. If fields is
+// empty, all fields of the data table are used as sort keys in order. Returns
+// an error if any of the fields does not exist.
+func (t *DataTable) Sort(fields ...string) error {
+	if len(fields) == 0 {
+		fields = t.fields
+	}
+
+	cols := make([]int, len(fields))
+	for i, field := range fields {
+		col, err := t.lookupField(field)
+		if err != nil {
+			return err
+		}
+
+		cols[i] = col
+	}
+
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		a, b := t.rows[i], t.rows[j]
+
+		for _, col := range cols {
+			if a[col] != b[col] {
+				return a[col] < b[col]
+			}
+		}
+
+		return false
+	})
+
+	t.invalidateIndex()
+
+	return nil
+}
+
+// SortFunc performs a stable sort of the data table rows using less to compare
+// rows. The rows are passed to less as maps of field names to values.
+func (t *DataTable) SortFunc(less func(a, b map[string]string) bool) {
+	rows := t.Rows()
+
+	indices := make([]int, len(t.rows))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		return less(rows[indices[i]], rows[indices[j]])
+	})
+
+	sorted := make([][]string, len(t.rows))
+	for i, index := range indices {
+		sorted[i] = t.rows[index]
+	}
+
+	t.rows = sorted
+	t.invalidateIndex()
+}
+
+// Reverse reverses the order of the data table rows in place.
+func (t *DataTable) Reverse() {
+	for i, j := 0, len(t.rows)-1; i < j; i, j = i+1, j-1 {
+		t.rows[i], t.rows[j] = t.rows[j], t.rows[i]
+	}
+
+	t.invalidateIndex()
+}
+
+// Reversed returns a new data table containing the rows of the receiver in
+// reverse order. The new data table has the same fields and options as the
+// receiver, which is left unchanged.
+func (t *DataTable) Reversed() *DataTable {
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[len(t.rows)-1-i] = copyValues(row)
+	}
+
+	return t.derive(rows)
+}
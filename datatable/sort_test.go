@@ -0,0 +1,141 @@
+package datatable
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := []struct {
+		name        string
+		fields      []string
+		expected    [][]string
+		expectError bool
+	}{
+		{
+			name:   "single field",
+			fields: []string{"name"},
+			expected: [][]string{
+				{"bar", "2", "b"},
+				{"bar", "10", "a"},
+				{"foo", "1", "c"},
+			},
+		},
+		{
+			name:   "multiple fields",
+			fields: []string{"name", "tag"},
+			expected: [][]string{
+				{"bar", "10", "a"},
+				{"bar", "2", "b"},
+				{"foo", "1", "c"},
+			},
+		},
+		{
+			name: "all fields",
+			expected: [][]string{
+				{"bar", "10", "a"},
+				{"bar", "2", "b"},
+				{"foo", "1", "c"},
+			},
+		},
+		{
+			name:        "unknown field",
+			fields:      []string{"name", "unknown"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := New(
+				[]string{"name", "value", "tag"},
+				[]string{"foo", "1", "c"},
+				[]string{"bar", "2", "b"},
+				[]string{"bar", "10", "a"},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			err = dt.Sort(tc.fields...)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(dt.RowValues(), tc.expected) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expected, dt.RowValues())
+			}
+		})
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"foo", "10"},
+		[]string{"bar", "2"},
+		[]string{"baz", "10"},
+		[]string{"qux", "1"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	dt.SortFunc(func(a, b map[string]string) bool {
+		x, _ := strconv.Atoi(a["value"])
+		y, _ := strconv.Atoi(b["value"])
+		return x < y
+	})
+
+	expected := [][]string{
+		{"qux", "1"},
+		{"bar", "2"},
+		{"foo", "10"},
+		{"baz", "10"},
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+}
+
+func TestReverse(t *testing.T) {
+	cases := []struct {
+		name     string
+		rows     [][]string
+		expected [][]string
+	}{
+		{name: "empty", rows: [][]string{}, expected: [][]string{}},
+		{name: "single row", rows: [][]string{{"1"}}, expected: [][]string{{"1"}}},
+		{name: "even", rows: [][]string{{"1"}, {"2"}}, expected: [][]string{{"2"}, {"1"}}},
+		{name: "odd", rows: [][]string{{"1"}, {"2"}, {"3"}}, expected: [][]string{{"3"}, {"2"}, {"1"}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := New([]string{"value"}, tc.rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			reversed := dt.Reversed()
+
+			if !reflect.DeepEqual(reversed.RowValues(), tc.expected) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expected, reversed.RowValues())
+			}
+
+			dt.Reverse()
+
+			if !reflect.DeepEqual(dt.RowValues(), tc.expected) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expected, dt.RowValues())
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// tagName is the name of the struct tag that can be used to map struct fields
+// to data table fields.
+const tagName = "datatable"
+
+// structField describes a struct field that can be mapped to a data table
+// field.
+type structField struct {
+	name   string
+	index  int
+	tagged bool
+}
+
+// matches returns true if the struct field should be mapped to field. Tagged
+// struct fields must match exactly, untagged fields are matched
+// case-insensitively.
+func (f structField) matches(field string) bool {
+	if f.tagged {
+		return f.name == field
+	}
+
+	return strings.EqualFold(f.name, field)
+}
+
+// Unmarshal populates dest with the rows of the data table. Dest must be a
+// pointer to a slice of structs. Data table fields are mapped to struct fields
+// using the `datatable:"fieldname"` tag. Untagged exported struct fields are
+// matched case-insensitively by their name. Struct fields tagged with
+// `datatable:"-"` are ignored. Cell values are converted into the type of the
+// struct field. Supported types are strings, bools, ints, uints and floats.
+// Empty cells leave the struct field at its zero value.
+func (t *DataTable) Unmarshal(dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.New("destination must be a non-nil pointer to a slice of structs")
+	}
+
+	slice := v.Elem()
+
+	elemType := slice.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a pointer to a slice of structs, got slice of %s", elemType)
+	}
+
+	mapping := t.fieldMapping(elemType)
+
+	out := reflect.MakeSlice(slice.Type(), len(t.rows), len(t.rows))
+
+	for i := range t.rows {
+		if err := t.unmarshalRow(i, mapping, out.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	slice.Set(out)
+
+	return nil
+}
+
+// UnmarshalRow populates dest with the row at index. Dest must be a pointer to
+// a struct. See Unmarshal for details about the field mapping and type
+// conversion. Returns an error if index is out of bounds.
+func (t *DataTable) UnmarshalRow(index int, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("destination must be a non-nil pointer to a struct")
+	}
+
+	if err := t.checkRowIndex(index); err != nil {
+		return err
+	}
+
+	elem := v.Elem()
+
+	return t.unmarshalRow(index, t.fieldMapping(elem.Type()), elem)
+}
+
+// FromStructs creates a new DataTable from items, which must be a slice of
+// structs. The data table fields are derived from the `datatable:"fieldname"`
+// tags or the names of the exported struct fields. Struct fields tagged with
+// `datatable:"-"` are ignored. Rows are created by converting each struct
+// field value to its string representation. Supported types are strings,
+// bools, ints, uints, floats and types implementing fmt.Stringer.
+func FromStructs(items interface{}) (*DataTable, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return nil, errors.New("items must be a slice of structs")
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("items must be a slice of structs, got slice of %s", elemType)
+	}
+
+	sfs := structFields(elemType)
+
+	fields := make([]string, len(sfs))
+	for i, sf := range sfs {
+		f := elemType.Field(sf.index)
+		if !canFormat(f.Type) {
+			return nil, fmt.Errorf("field %q: unsupported type %s", f.Name, f.Type)
+		}
+
+		fields[i] = sf.name
+	}
+
+	rows := make([][]string, v.Len())
+	for i := range rows {
+		elem := v.Index(i)
+
+		row := make([]string, len(sfs))
+		for j, sf := range sfs {
+			row[j] = formatValue(elem.Field(sf.index))
+		}
+
+		rows[i] = row
+	}
+
+	return New(fields, rows...)
+}
+
+// fieldMapping maps the column indices of the data table to the indices of
+// the matching fields of struct type typ. Columns without matching struct
+// field are mapped to -1.
+func (t *DataTable) fieldMapping(typ reflect.Type) []int {
+	mapping := make([]int, len(t.fields))
+	for i := range mapping {
+		mapping[i] = -1
+	}
+
+	for _, sf := range structFields(typ) {
+		for col, field := range t.fields {
+			if sf.matches(field) {
+				mapping[col] = sf.index
+				break
+			}
+		}
+	}
+
+	return mapping
+}
+
+// unmarshalRow sets the fields of the struct value v to the values of the row
+// at index using mapping.
+func (t *DataTable) unmarshalRow(index int, mapping []int, v reflect.Value) error {
+	row := t.rows[index]
+
+	for col, fieldIndex := range mapping {
+		if fieldIndex < 0 || row[col] == "" {
+			continue
+		}
+
+		if err := setValue(v.Field(fieldIndex), row[col]); err != nil {
+			return fmt.Errorf("row %d, field %q: %s", index, t.fields[col], err.Error())
+		}
+	}
+
+	return nil
+}
+
+// structFields returns all exported fields of struct type typ that are not
+// explicitly ignored via struct tag.
+func structFields(typ reflect.Type) []structField {
+	fields := make([]structField, 0, typ.NumField())
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		tag := f.Tag.Get(tagName)
+
+		switch tag {
+		case "-":
+			continue
+		case "":
+			fields = append(fields, structField{name: f.Name, index: i})
+		default:
+			fields = append(fields, structField{name: tag, index: i, tagged: true})
+		}
+	}
+
+	return fields
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// canFormat returns true if values of typ can be converted to string using
+// formatValue.
+func canFormat(typ reflect.Type) bool {
+	if typ.Implements(stringerType) {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatValue converts v into its string representation. Callers must ensure
+// that the type of v is supported by checking it with canFormat first.
+func formatValue(v reflect.Value) string {
+	if v.Type().Implements(stringerType) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return ""
+		}
+
+		return v.Interface().(fmt.Stringer).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	default:
+		return v.String()
+	}
+}
+
+// setValue converts s into the type of v and sets it.
+func setValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", s, v.Type())
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", s, v.Type())
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", s, v.Type())
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s", s, v.Type())
+		}
+
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
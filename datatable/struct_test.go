@@ -0,0 +1,226 @@
+package datatable
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type testStruct struct {
+	Name    string
+	Count   int     `datatable:"count"`
+	Total   int64   `datatable:"total"`
+	Ratio   float64 `datatable:"the ratio"`
+	Enabled bool
+	Ignored string `datatable:"-"`
+	private string
+}
+
+func TestUnmarshal(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "count", "total", "the ratio", "ENABLED", "Ignored", "unmapped"},
+		[]string{"foo", "1", "100", "0.5", "true", "x", "y"},
+		[]string{"bar", "", "-3", "1", "false", "x", "y"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var result []testStruct
+
+	if err := dt.Unmarshal(&result); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []testStruct{
+		{Name: "foo", Count: 1, Total: 100, Ratio: 0.5, Enabled: true},
+		{Name: "bar", Count: 0, Total: -3, Ratio: 1, Enabled: false},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, result)
+	}
+}
+
+func TestUnmarshalConversionError(t *testing.T) {
+	dt, err := New([]string{"name", "count"}, []string{"foo", "1"}, []string{"bar", "baz"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var result []testStruct
+
+	err = dt.Unmarshal(&result)
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	expected := `row 1, field "count": cannot convert "baz" to int`
+
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestUnmarshalInvalidDestination(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var structs []testStruct
+	var strs []string
+
+	for _, dest := range []interface{}{nil, structs, &strs, &testStruct{}} {
+		if err := dt.Unmarshal(dest); err == nil {
+			t.Fatalf("expected error for destination %#v but got nil", dest)
+		}
+	}
+}
+
+func TestUnmarshalRow(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "count"},
+		[]string{"foo", "1"},
+		[]string{"bar", "2"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var result testStruct
+
+	if err := dt.UnmarshalRow(1, &result); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := testStruct{Name: "bar", Count: 2}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, result)
+	}
+
+	if err := dt.UnmarshalRow(2, &result); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.UnmarshalRow(0, result); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	var results []testStruct
+
+	if err := dt.UnmarshalRow(0, &results); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+type testStringer struct {
+	value string
+}
+
+func (s testStringer) String() string {
+	return "<" + s.value + ">"
+}
+
+func TestFromStructs(t *testing.T) {
+	items := []struct {
+		Name    string
+		Count   uint `datatable:"count"`
+		Ratio   float32
+		Enabled bool
+		Value   testStringer `datatable:"value"`
+		Ignored []string     `datatable:"-"`
+	}{
+		{Name: "foo", Count: 1, Ratio: 0.5, Enabled: true, Value: testStringer{"a"}},
+		{Name: "bar", Count: 2, Ratio: -1.25, Value: testStringer{"b"}},
+	}
+
+	dt, err := FromStructs(items)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"Name", "count", "Ratio", "Enabled", "value"}
+	expectedRows := [][]string{
+		{"foo", "1", "0.5", "true", "<a>"},
+		{"bar", "2", "-1.25", "false", "<b>"},
+	}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+}
+
+func TestFromStructsNilValues(t *testing.T) {
+	items := []struct {
+		Stringer fmt.Stringer
+		Pointer  *testStringer
+	}{
+		{},
+		{Stringer: testStringer{"a"}, Pointer: &testStringer{"b"}},
+	}
+
+	dt, err := FromStructs(items)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"", ""}, {"<a>", "<b>"}}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+}
+
+func TestFromStructsRoundTrip(t *testing.T) {
+	items := []testStruct{
+		{Name: "foo", Count: 1, Total: 100, Ratio: 0.5, Enabled: true},
+		{Name: "bar", Count: 2, Total: -3, Ratio: 1},
+	}
+
+	dt, err := FromStructs(items)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var result []testStruct
+
+	if err := dt.Unmarshal(&result); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(result, items) {
+		t.Fatalf("expected %#v, got %#v", items, result)
+	}
+}
+
+func TestFromStructsErrors(t *testing.T) {
+	_, err := FromStructs([]struct {
+		Name  string
+		Attrs map[string]string
+	}{})
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	expected := `field "Attrs": unsupported type map[string]string`
+
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err.Error())
+	}
+
+	if _, err := FromStructs(testStruct{}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := FromStructs([]string{"foo"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
@@ -0,0 +1,130 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler. The data table is rendered in
+// the pipe-delimited gherkin format produced by String. Leading and trailing
+// whitespace of cell values is escaped, so that UnmarshalText restores all
+// values exactly.
+func (t *DataTable) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It parses data in the
+// pipe-delimited gherkin format produced by String and replaces the fields and
+// rows of the data table. The first row contains the fields. Blank lines are
+// ignored and leading and trailing whitespace of cell values is trimmed unless
+// it is escaped, e.g. as `\s` for a space or `\t` for a tab. The data table
+// is validated against its current options. Returns an error if data is
+// malformed or fails validation.
+func (t *DataTable) UnmarshalText(data []byte) error {
+	table := make([][]string, 0)
+
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		cells, err := parseTextRow(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %s", i+1, err.Error())
+		}
+
+		table = append(table, cells)
+	}
+
+	if len(table) == 0 {
+		return errors.New("data table must have at least a header row")
+	}
+
+	dt, err := NewWithOptions(t.options, table[0], table[1:]...)
+	if err != nil {
+		return err
+	}
+
+	*t = *dt
+
+	return nil
+}
+
+// parseTextRow parses a single pipe-delimited row and unescapes its cell
+// values.
+func parseTextRow(line string) ([]string, error) {
+	if !strings.HasPrefix(line, "|") {
+		return nil, errors.New(`row must start with "|"`)
+	}
+
+	cells := make([]string, 0)
+
+	runes := []rune(line[1:])
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '|':
+			cells = append(cells, unescapeText(strings.TrimSpace(string(runes[start:i]))))
+			start = i + 1
+		}
+	}
+
+	if start < len(runes) && strings.TrimSpace(string(runes[start:])) != "" {
+		return nil, errors.New(`row must end with "|"`)
+	}
+
+	return cells, nil
+}
+
+// textEscapes maps the characters of escape sequences to their unescaped
+// values.
+var textEscapes = map[rune]rune{
+	'n':  '\n',
+	's':  ' ',
+	't':  '\t',
+	'r':  '\r',
+	'v':  '\v',
+	'f':  '\f',
+	'|':  '|',
+	'\\': '\\',
+}
+
+// unescapeText reverts the escaping applied by String. Unknown escape
+// sequences are kept as is.
+func unescapeText(s string) string {
+	var sb strings.Builder
+
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '\\' || i+1 == len(runes) {
+			sb.WriteRune(r)
+			continue
+		}
+
+		if unescaped, ok := textEscapes[runes[i+1]]; ok {
+			sb.WriteRune(unescaped)
+			i++
+			continue
+		}
+
+		if runes[i+1] == 'u' && i+6 <= len(runes) {
+			if code, err := strconv.ParseUint(string(runes[i+2:i+6]), 16, 32); err == nil {
+				sb.WriteRune(rune(code))
+				i += 5
+				continue
+			}
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,128 @@
+package datatable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"foo", "a|b"},
+		[]string{`back\slash`, "multi\nline"},
+		[]string{"", "ünïcode"},
+		[]string{" x ", "\t"},
+		[]string{"\u3000wide", `\s\t`},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	data, err := dt.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if string(data) != dt.String() {
+		t.Fatalf("expected %q, got %q", dt.String(), string(data))
+	}
+
+	var result DataTable
+
+	if err := result.UnmarshalText(data); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !result.Equal(dt) {
+		t.Fatalf("expected %#v, got %#v", dt.RowValues(), result.RowValues())
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	cases := []struct {
+		name           string
+		data           string
+		expectedFields []string
+		expectedRows   [][]string
+		expectError    bool
+	}{
+		{
+			name:           "header only",
+			data:           "| name | value |\n",
+			expectedFields: []string{"name", "value"},
+			expectedRows:   [][]string{},
+		},
+		{
+			name:           "blank lines and indentation",
+			data:           "\n  | name | value |\n\n  | foo  | \\x   |\n",
+			expectedFields: []string{"name", "value"},
+			expectedRows:   [][]string{{"foo", `\x`}},
+		},
+		{
+			name:           "escaped whitespace",
+			data:           "| name | value |\n| \\sfoo\\t | \\u00a0\\u12 |\n",
+			expectedFields: []string{"name", "value"},
+			expectedRows:   [][]string{{" foo\t", "\u00a0\\u12"}},
+		},
+		{
+			name:        "empty",
+			data:        "\n",
+			expectError: true,
+		},
+		{
+			name:        "missing leading pipe",
+			data:        "name | value |",
+			expectError: true,
+		},
+		{
+			name:        "missing trailing pipe",
+			data:        "| name | value",
+			expectError: true,
+		},
+		{
+			name:        "mismatched row length",
+			data:        "| name | value |\n| foo |",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dt DataTable
+
+			err := dt.UnmarshalText([]byte(tc.data))
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(dt.Fields(), tc.expectedFields) {
+				t.Fatalf("expected fields %#v, got %#v", tc.expectedFields, dt.Fields())
+			}
+
+			if !reflect.DeepEqual(dt.RowValues(), tc.expectedRows) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expectedRows, dt.RowValues())
+			}
+		})
+	}
+}
+
+func TestUnmarshalTextValidatesOptions(t *testing.T) {
+	dt, err := NewWithOptions(&Options{FieldTypes: map[string]FieldType{"value": Int}}, []string{"name", "value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.UnmarshalText([]byte("| name | value |\n| foo | bar |")); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if dt.Len() != 0 {
+		t.Fatalf("expected data table to be unchanged, got %d rows", dt.Len())
+	}
+}
@@ -0,0 +1,615 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Filter returns a new data table containing only the rows for which
+// predicate returns true. The new data table has the same fields and options
+// as the receiver, which is left unchanged.
+func (t *DataTable) Filter(predicate func(row map[string]string) bool) *DataTable {
+	rows := make([][]string, 0)
+
+	for i, row := range t.Rows() {
+		if predicate(row) {
+			rows = append(rows, copyValues(t.rows[i]))
+		}
+	}
+
+	return t.derive(rows)
+}
+
+// Map returns a new data table where the value of each cell is replaced by the
+// result of passing the cell's field and value to fn. The new data table has
+// the same fields and options as the receiver, which is left unchanged.
+func (t *DataTable) Map(fn func(field, value string) string) *DataTable {
+	rows := make([][]string, len(t.rows))
+
+	for i, row := range t.rows {
+		mapped := make([]string, len(row))
+		for j, value := range row {
+			mapped[j] = fn(t.fields[j], value)
+		}
+
+		rows[i] = mapped
+	}
+
+	return t.derive(rows)
+}
+
+// Apply returns a new data table with given fields whose rows are the result
+// of passing each row of the receiver to fn. The row is passed to fn as a map
+// of field names to values and fn must return the values of the new row in
+// the order of fields. The new data table has no options. Returns an error if
+// fn returns a row whose length does not match the number of fields.
+func (t *DataTable) Apply(fields []string, fn func(row map[string]string) []string) (*DataTable, error) {
+	rows := make([][]string, len(t.rows))
+
+	for i, row := range t.rows {
+		values := fn(t.rowMap(row))
+		if len(values) != len(fields) {
+			return nil, fmt.Errorf("row %d: expected row length of %d, got %d", i, len(fields), len(values))
+		}
+
+		rows[i] = values
+	}
+
+	return New(copyValues(fields), rows...)
+}
+
+// MapColumn replaces the value of every cell of field in place by the result
+// of passing it to fn. Returns an error if the field does not exist.
+func (t *DataTable) MapColumn(field string, fn func(value string) string) error {
+	col, err := t.lookupField(field)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range t.rows {
+		row[col] = fn(row[col])
+	}
+
+	t.invalidateIndex()
+
+	return nil
+}
+
+// Substitute returns a new data table where every occurrence of a ${key}
+// placeholder in cell values is replaced by vars[key]. Placeholders whose key
+// is not present in vars are left untouched. The new data table has the same
+// fields and options as the receiver, which is left unchanged.
+func (t *DataTable) Substitute(vars map[string]string) *DataTable {
+	return t.SubstituteWithDelims("${", "}", vars)
+}
+
+// SubstituteWithDelims is like Substitute but uses left and right as
+// placeholder delimiters instead of "${" and "}". Empty delimiters are
+// invalid, so if left or right is empty, cell values are left unchanged.
+func (t *DataTable) SubstituteWithDelims(left, right string, vars map[string]string) *DataTable {
+	return t.substitute(left, right, func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	})
+}
+
+// SubstituteFunc is like Substitute but resolves the keys of ${key}
+// placeholders by calling fn. Placeholders for which fn returns false are left
+// untouched.
+func (t *DataTable) SubstituteFunc(fn func(key string) (string, bool)) *DataTable {
+	return t.substitute("${", "}", fn)
+}
+
+// substitute returns a new data table where all placeholders enclosed by left
+// and right in cell values are replaced by the values returned by lookup.
+func (t *DataTable) substitute(left, right string, lookup func(key string) (string, bool)) *DataTable {
+	return t.Map(func(_, value string) string {
+		return substitute(value, left, right, lookup)
+	})
+}
+
+// ReplaceAll returns a new data table where all occurrences of old in cell
+// values are replaced by repl. The new data table has the same fields and
+// options as the receiver, which is left unchanged.
+func (t *DataTable) ReplaceAll(old, repl string) *DataTable {
+	return t.Map(func(_, value string) string {
+		return strings.ReplaceAll(value, old, repl)
+	})
+}
+
+// ReplaceRegex returns a new data table where all matches of re in cell
+// values are replaced by repl. Inside repl, $ signs are interpreted as in
+// regexp.Regexp.ReplaceAllString. The new data table has the same fields and
+// options as the receiver, which is left unchanged.
+func (t *DataTable) ReplaceRegex(re *regexp.Regexp, repl string) *DataTable {
+	return t.Map(func(_, value string) string {
+		return re.ReplaceAllString(value, repl)
+	})
+}
+
+// MaskPlaceholder is the value that replaces masked cell values.
+const MaskPlaceholder = "<masked>"
+
+// Mask returns a new data table where the values of given fields are replaced
+// by MaskPlaceholder. This is useful to exclude nondeterministic values like
+// timestamps or generated IDs from comparisons. The new data table has the
+// same fields and options as the receiver, which is left unchanged. Returns an
+// error if any of the fields does not exist.
+func (t *DataTable) Mask(fields ...string) (*DataTable, error) {
+	masked := make([]bool, len(t.fields))
+
+	for _, field := range fields {
+		col, err := t.lookupField(field)
+		if err != nil {
+			return nil, err
+		}
+
+		masked[col] = true
+	}
+
+	rows := make([][]string, len(t.rows))
+
+	for i, row := range t.rows {
+		rows[i] = copyValues(row)
+
+		for j := range row {
+			if masked[j] {
+				rows[i][j] = MaskPlaceholder
+			}
+		}
+	}
+
+	return t.derive(rows), nil
+}
+
+// ExpandEnv returns a new data table where $VAR and ${VAR} references in cell
+// values are replaced by the values of the corresponding environment
+// variables. References to undefined variables are replaced by the empty
+// string. The new data table has the same fields and options as the receiver,
+// which is left unchanged.
+func (t *DataTable) ExpandEnv() *DataTable {
+	return t.Map(func(_, value string) string {
+		return os.ExpandEnv(value)
+	})
+}
+
+// NormalizeOptions configures the transformations applied by Normalize. They
+// are applied in the order of the struct fields.
+type NormalizeOptions struct {
+	// Transform is applied to every value first if it is non-nil. It can be
+	// used for transformations like unicode normalization, e.g. by passing
+	// norm.NFC.String from golang.org/x/text/unicode/norm.
+	Transform func(string) string
+
+	// TrimSpace removes leading and trailing whitespace.
+	TrimSpace bool
+
+	// CollapseInnerWhitespace replaces every run of whitespace with a single
+	// space.
+	CollapseInnerWhitespace bool
+
+	// LowerCase converts values to lower case.
+	LowerCase bool
+}
+
+// whitespace matches runs of whitespace characters.
+var whitespace = regexp.MustCompile(`\s+`)
+
+// normalize applies the configured transformations to value.
+func (o NormalizeOptions) normalize(value string) string {
+	if o.Transform != nil {
+		value = o.Transform(value)
+	}
+
+	if o.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+
+	if o.CollapseInnerWhitespace {
+		value = whitespace.ReplaceAllString(value, " ")
+	}
+
+	if o.LowerCase {
+		value = strings.ToLower(value)
+	}
+
+	return value
+}
+
+// Normalize returns a new data table where every cell value is normalized
+// according to opts. The new data table has the same fields and options as
+// the receiver, which is left unchanged.
+func (t *DataTable) Normalize(opts NormalizeOptions) *DataTable {
+	return t.Map(func(_, value string) string {
+		return opts.normalize(value)
+	})
+}
+
+// Transpose returns a new data table with rows and columns swapped. The data
+// table including its fields is treated as a matrix which is transposed, so
+// that the original fields become the first column of the new data table and
+// the values of the original first column become the new fields. For example:
+//
+//	| name | foo |           | name | age |
+//	| age  | 42  |   becomes | foo  | 42  |
+//
+// This is useful for vertical "property | value" tables. Transposing a data
+// table twice yields the original data table. Since the values of the original
+// first column may repeat, the new data table has options with only
+// AllowDuplicateFields set. Returns an error if the data table has no rows.
+func (t *DataTable) Transpose() (*DataTable, error) {
+	if len(t.rows) == 0 {
+		return nil, errors.New("cannot transpose data table without rows")
+	}
+
+	grid := make([][]string, len(t.fields))
+
+	for i, field := range t.fields {
+		values := make([]string, len(t.rows)+1)
+		values[0] = field
+
+		for j, row := range t.rows {
+			values[j+1] = row[i]
+		}
+
+		grid[i] = values
+	}
+
+	return NewWithOptions(&Options{AllowDuplicateFields: true}, grid[0], grid[1:]...)
+}
+
+// Select returns a new data table containing only the columns of given fields
+// in the order they are passed. The new data table has no options, because
+// the options of the receiver may require fields that were not selected. The
+// receiver is left unchanged. Returns an error if any of the fields does not
+// exist or is selected more than once.
+func (t *DataTable) Select(fields ...string) (*DataTable, error) {
+	return t.View(fields, nil)
+}
+
+// View combines Select and Filter: it returns a new data table containing
+// only the columns of given fields and only the rows for which predicate
+// returns true. The predicate receives the rows with the selected fields only.
+// A nil predicate keeps all rows. Like Select, the new data table has no
+// options and the receiver is left unchanged. Returns an error if any of the
+// fields does not exist or is selected more than once.
+func (t *DataTable) View(fields []string, predicate func(row map[string]string) bool) (*DataTable, error) {
+	cols, err := t.selectColumns(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	dt := &DataTable{
+		fields: columnValues(t.fields, cols),
+		rows:   make([][]string, 0, len(t.rows)),
+	}
+
+	for _, row := range t.rows {
+		values := columnValues(row, cols)
+
+		if predicate == nil || predicate(dt.rowMap(values)) {
+			dt.rows = append(dt.rows, values)
+		}
+	}
+
+	return dt, nil
+}
+
+// SubTable combines Select and Slice: it returns a new data table containing
+// only the columns of given fields and the rows in the half-open range
+// [start, end). Like Select, the new data table has no options and the
+// receiver is left unchanged. Returns an error if any of the fields does not
+// exist or is selected more than once, if start or end are out of bounds or if
+// start is greater than end.
+func (t *DataTable) SubTable(fields []string, start, end int) (*DataTable, error) {
+	if err := t.checkSliceBounds(start, end); err != nil {
+		return nil, err
+	}
+
+	cols, err := t.selectColumns(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, end-start)
+	for _, row := range t.rows[start:end] {
+		rows = append(rows, columnValues(row, cols))
+	}
+
+	return &DataTable{
+		fields: columnValues(t.fields, cols),
+		rows:   rows,
+	}, nil
+}
+
+// selectColumns returns the column indices of fields. Returns an error if any
+// of the fields does not exist or is contained more than once.
+func (t *DataTable) selectColumns(fields []string) ([]int, error) {
+	cols := make([]int, len(fields))
+
+	for i, field := range fields {
+		col, err := t.lookupField(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if indexOfInt(cols[:i], col) >= 0 {
+			return nil, fmt.Errorf("field %q selected more than once", field)
+		}
+
+		cols[i] = col
+	}
+
+	return cols, nil
+}
+
+// Pivot reshapes a long data table into a wide one. The distinct values of
+// keyField become new fields, populated with the corresponding values of
+// valueField. All other fields are grouping fields: rows that share the same
+// values for all grouping fields are collapsed into a single row. For example:
+//
+//	| host | metric | value |           | host | cpu | mem |
+//	| a    | cpu    | 10    |   becomes | a    | 10  | 20  |
+//	| a    | mem    | 20    |           | b    | 30  |     |
+//	| b    | cpu    | 30    |
+//
+// The new fields are the grouping fields followed by the values of keyField in
+// order of their first occurrence. Rows are ordered by the first occurrence of
+// their group. Combinations of group and key that do not occur in the data
+// table are left empty. The new data table has no options. Returns an error if
+// either field does not exist, if both name the same field, if a key value
+// collides with a grouping field or if a combination of group and key occurs
+// more than once.
+func (t *DataTable) Pivot(keyField, valueField string) (*DataTable, error) {
+	keyCol, err := t.lookupField(keyField)
+	if err != nil {
+		return nil, err
+	}
+
+	valueCol, err := t.lookupField(valueField)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyCol == valueCol {
+		return nil, fmt.Errorf("key field and value field must differ, got %q for both", t.fields[keyCol])
+	}
+
+	groupCols := make([]int, 0, len(t.fields)-2)
+	for i := range t.fields {
+		if i != keyCol && i != valueCol {
+			groupCols = append(groupCols, i)
+		}
+	}
+
+	fields := columnValues(t.fields, groupCols)
+	keyIndices := make(map[string]int)
+
+	for _, row := range t.rows {
+		key := row[keyCol]
+		if _, ok := keyIndices[key]; ok {
+			continue
+		}
+
+		if contains(fields, key) {
+			return nil, fmt.Errorf("value %q of field %q collides with an existing field", key, t.fields[keyCol])
+		}
+
+		keyIndices[key] = len(fields)
+		fields = append(fields, key)
+	}
+
+	groupIndices := make(map[string]int)
+	rows := make([][]string, 0)
+	set := make([][]bool, 0)
+
+	for i, row := range t.rows {
+		group := columnValues(row, groupCols)
+		k := compositeKey(group)
+
+		index, ok := groupIndices[k]
+		if !ok {
+			index = len(rows)
+			groupIndices[k] = index
+
+			newRow := make([]string, len(fields))
+			copy(newRow, group)
+
+			rows = append(rows, newRow)
+			set = append(set, make([]bool, len(fields)))
+		}
+
+		col := keyIndices[row[keyCol]]
+		if set[index][col] {
+			return nil, fmt.Errorf("row %d: duplicate value for key %q in the same group", i, row[keyCol])
+		}
+
+		rows[index][col] = row[valueCol]
+		set[index][col] = true
+	}
+
+	return &DataTable{fields: fields, rows: rows}, nil
+}
+
+// Head returns a new data table containing at most the first n rows. The new
+// data table has the same fields and options as the receiver, which is left
+// unchanged.
+func (t *DataTable) Head(n int) *DataTable {
+	n = clamp(n, 0, len(t.rows))
+
+	return t.derive(copyRows(t.rows[:n]))
+}
+
+// Tail returns a new data table containing at most the last n rows. The new
+// data table has the same fields and options as the receiver, which is left
+// unchanged.
+func (t *DataTable) Tail(n int) *DataTable {
+	n = clamp(n, 0, len(t.rows))
+
+	return t.derive(copyRows(t.rows[len(t.rows)-n:]))
+}
+
+// Slice returns a new data table containing the rows in the half-open range
+// [start, end). The new data table has the same fields and options as the
+// receiver, which is left unchanged. Returns an error if start or end are out
+// of bounds or if start is greater than end.
+func (t *DataTable) Slice(start, end int) (*DataTable, error) {
+	if err := t.checkSliceBounds(start, end); err != nil {
+		return nil, err
+	}
+
+	return t.derive(copyRows(t.rows[start:end])), nil
+}
+
+// checkSliceBounds returns an error if the half-open row range [start, end) is
+// out of bounds or if start is greater than end.
+func (t *DataTable) checkSliceBounds(start, end int) error {
+	if start < 0 || end > len(t.rows) || start > end {
+		return fmt.Errorf("slice bounds [%d:%d] out of range, data table has %d rows", start, end, len(t.rows))
+	}
+
+	return nil
+}
+
+// Distinct returns a new data table containing only the first occurrence of
+// each unique row. The new data table has the same fields and options as the
+// receiver, which is left unchanged.
+func (t *DataTable) Distinct() *DataTable {
+	cols := make([]int, len(t.fields))
+	for i := range cols {
+		cols[i] = i
+	}
+
+	return t.distinct(cols)
+}
+
+// DistinctBy is like Distinct but only compares the values of given fields to
+// determine whether rows are duplicates. If fields is empty, all fields are
+// compared like in Distinct. Returns an error if any of the fields does not
+// exist.
+func (t *DataTable) DistinctBy(fields ...string) (*DataTable, error) {
+	if len(fields) == 0 {
+		return t.Distinct(), nil
+	}
+
+	cols := make([]int, len(fields))
+	for i, field := range fields {
+		col, err := t.lookupField(field)
+		if err != nil {
+			return nil, err
+		}
+
+		cols[i] = col
+	}
+
+	return t.distinct(cols), nil
+}
+
+// distinct returns a new data table containing only the first row for each
+// unique combination of values in cols.
+func (t *DataTable) distinct(cols []int) *DataTable {
+	seen := make(map[string]bool, len(t.rows))
+	rows := make([][]string, 0)
+
+	for _, row := range t.rows {
+		key := compositeKey(columnValues(row, cols))
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		rows = append(rows, copyValues(row))
+	}
+
+	return t.derive(rows)
+}
+
+// derive creates a new data table with a copy of the receiver's fields, the
+// receiver's options and given rows.
+func (t *DataTable) derive(rows [][]string) *DataTable {
+	return &DataTable{
+		fields:  copyValues(t.fields),
+		rows:    rows,
+		options: t.options,
+	}
+}
+
+// substitute replaces all placeholders in s that are enclosed by left and
+// right with the value returned by lookup for their key. Placeholders for
+// which lookup returns false are left untouched. Returns s unchanged if left
+// or right is empty.
+func substitute(s, left, right string, lookup func(key string) (string, bool)) string {
+	if left == "" || right == "" {
+		return s
+	}
+
+	var sb strings.Builder
+
+	for {
+		start := strings.Index(s, left)
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(s[start+len(left):], right)
+		if end < 0 {
+			break
+		}
+
+		end += start + len(left)
+
+		key := s[start+len(left) : end]
+
+		sb.WriteString(s[:start])
+
+		if value, ok := lookup(key); ok {
+			sb.WriteString(value)
+		} else {
+			sb.WriteString(s[start : end+len(right)])
+		}
+
+		s = s[end+len(right):]
+	}
+
+	sb.WriteString(s)
+
+	return sb.String()
+}
+
+// copyRows returns a deep copy of rows.
+func copyRows(rows [][]string) [][]string {
+	c := make([][]string, len(rows))
+	for i, row := range rows {
+		c[i] = copyValues(row)
+	}
+
+	return c
+}
+
+// clamp limits n to the range [min, max].
+func clamp(n, min, max int) int {
+	if n < min {
+		return min
+	}
+
+	if n > max {
+		return max
+	}
+
+	return n
+}
+
+// copyValues returns a copy of values.
+func copyValues(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	c := make([]string, len(values))
+	copy(c, values)
+
+	return c
+}
@@ -0,0 +1,902 @@
+package datatable
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	options := &Options{RequiredFields: []string{"name"}}
+
+	dt, err := NewWithOptions(
+		options,
+		[]string{"name", "status"},
+		[]string{"foo", "active"},
+		[]string{"bar", "inactive"},
+		[]string{"baz", "active"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	filtered := dt.Filter(func(row map[string]string) bool {
+		return row["status"] == "active"
+	})
+
+	expected := [][]string{{"foo", "active"}, {"baz", "active"}}
+
+	if !reflect.DeepEqual(filtered.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, filtered.RowValues())
+	}
+
+	if !reflect.DeepEqual(filtered.Fields(), dt.Fields()) {
+		t.Fatalf("expected fields %#v, got %#v", dt.Fields(), filtered.Fields())
+	}
+
+	if filtered.options != options {
+		t.Fatal("expected options to be carried over")
+	}
+
+	if dt.Len() != 3 {
+		t.Fatalf("expected receiver to be unchanged, got %d rows", dt.Len())
+	}
+}
+
+func TestMap(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	mapped := dt.Map(func(field, value string) string {
+		return field + "=" + value
+	})
+
+	expected := [][]string{
+		{"one=1", "two=2", "three=3"},
+		{"one=4", "two=5", "three=6"},
+		{"one=7", "two=8", "three=9"},
+	}
+
+	if !reflect.DeepEqual(mapped.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, mapped.RowValues())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), rows) {
+		t.Fatalf("expected receiver to be unchanged, got %#v", dt.RowValues())
+	}
+}
+
+func TestApply(t *testing.T) {
+	dt, err := New(
+		[]string{"first", "last", "age"},
+		[]string{"John", "Doe", "42"},
+		[]string{"Jane", "Roe", "23"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	result, err := dt.Apply([]string{"name", "age"}, func(row map[string]string) []string {
+		return []string{row["first"] + " " + row["last"], row["age"]}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "age"}
+	expectedRows := [][]string{{"John Doe", "42"}, {"Jane Roe", "23"}}
+
+	if !reflect.DeepEqual(result.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, result.Fields())
+	}
+
+	if !reflect.DeepEqual(result.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, result.RowValues())
+	}
+
+	_, err = dt.Apply([]string{"name", "age"}, func(row map[string]string) []string {
+		return []string{row["first"]}
+	})
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestMapColumn(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	err = dt.MapColumn("two", func(value string) string {
+		return "<" + value + ">"
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{
+		{"1", "<2>", "3"},
+		{"4", "<5>", "6"},
+		{"7", "<8>", "9"},
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, dt.RowValues())
+	}
+
+	err = dt.MapColumn("unknown", func(value string) string {
+		return value
+	})
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value"},
+		[]string{"${USER_ID}", "id-${USER_ID}-${UNKNOWN}"},
+		[]string{"${HOST}:${PORT}", "${UNTERMINATED"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	vars := map[string]string{
+		"USER_ID": "42",
+		"HOST":    "localhost",
+		"PORT":    "8080",
+	}
+
+	expected := [][]string{
+		{"42", "id-42-${UNKNOWN}"},
+		{"localhost:8080", "${UNTERMINATED"},
+	}
+
+	if result := dt.Substitute(vars); !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	if dt.RowValues()[0][0] != "${USER_ID}" {
+		t.Fatalf("expected receiver to be unchanged, got %#v", dt.RowValues())
+	}
+}
+
+func TestSubstituteWithDelims(t *testing.T) {
+	dt, err := New([]string{"name"}, []string{"<<USER_ID>> ${USER_ID}"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	result := dt.SubstituteWithDelims("<<", ">>", map[string]string{"USER_ID": "42"})
+
+	expected := [][]string{{"42 ${USER_ID}"}}
+
+	if !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+}
+
+func TestSubstituteWithEmptyDelims(t *testing.T) {
+	dt, err := New([]string{"name"}, []string{"<<USER_ID>> ${USER_ID}"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	vars := map[string]string{"USER_ID": "42", "": "empty"}
+
+	cases := []struct {
+		name        string
+		left, right string
+	}{
+		{name: "both empty"},
+		{name: "left empty", right: ">>"},
+		{name: "right empty", left: "<<"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := dt.SubstituteWithDelims(tc.left, tc.right, vars)
+
+			if !reflect.DeepEqual(result.RowValues(), dt.RowValues()) {
+				t.Fatalf("expected rows %#v, got %#v", dt.RowValues(), result.RowValues())
+			}
+		})
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	dt, err := New(
+		[]string{"message", "level"},
+		[]string{"[ts] started", "info"},
+		[]string{"[ts] [ts] done", "info"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"started", "info"}, {"done", "info"}}
+
+	if result := dt.ReplaceAll("[ts] ", ""); !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	if dt.RowValues()[0][0] != "[ts] started" {
+		t.Fatalf("expected receiver to be unchanged, got %#v", dt.RowValues())
+	}
+}
+
+func TestReplaceRegex(t *testing.T) {
+	dt, err := New(
+		[]string{"id", "ref"},
+		[]string{"123e4567-e89b-12d3-a456-426614174000", "user-42"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	uuid := regexp.MustCompile(`[0-9a-f]{8}(-[0-9a-f]{4}){3}-[0-9a-f]{12}`)
+
+	expected := [][]string{{"<uuid>", "user-42"}}
+
+	if result := dt.ReplaceRegex(uuid, "<uuid>"); !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	expected = [][]string{{"123e4567-e89b-12d3-a456-426614174000", "42@user"}}
+
+	if result := dt.ReplaceRegex(regexp.MustCompile(`^(\w+)-(\d+)$`), "$2@$1"); !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+}
+
+func TestMask(t *testing.T) {
+	dt, err := New(
+		[]string{"id", "name", "created"},
+		[]string{"a1", "foo", "2019-01-01"},
+		[]string{"b2", "bar", "2019-01-02"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	masked, err := dt.Mask("id", "created")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{
+		{"<masked>", "foo", "<masked>"},
+		{"<masked>", "bar", "<masked>"},
+	}
+
+	if !reflect.DeepEqual(masked.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, masked.RowValues())
+	}
+
+	if dt.RowValues()[0][0] != "a1" {
+		t.Fatalf("expected receiver to be unchanged, got %#v", dt.RowValues())
+	}
+
+	if _, err := dt.Mask("id", "unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestSubstituteFunc(t *testing.T) {
+	dt, err := New([]string{"url"}, []string{"http://${host}:${port}/${path}"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	calls := make([]string, 0)
+
+	result := dt.SubstituteFunc(func(key string) (string, bool) {
+		calls = append(calls, key)
+
+		switch key {
+		case "host":
+			return "localhost", true
+		case "port":
+			return "8080", true
+		default:
+			return "", false
+		}
+	})
+
+	expected := [][]string{{"http://localhost:8080/${path}"}}
+
+	if !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	if expectedCalls := []string{"host", "port", "path"}; !reflect.DeepEqual(calls, expectedCalls) {
+		t.Fatalf("expected lookups %#v, got %#v", expectedCalls, calls)
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	os.Setenv("DATATABLE_TEST_HOST", "localhost")
+	defer os.Unsetenv("DATATABLE_TEST_HOST")
+
+	dt, err := New(
+		[]string{"url"},
+		[]string{"http://$DATATABLE_TEST_HOST/"},
+		[]string{"http://${DATATABLE_TEST_HOST}:${DATATABLE_TEST_UNDEFINED}/"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"http://localhost/"}, {"http://localhost:/"}}
+
+	if result := dt.ExpandEnv(); !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "city"},
+		[]string{"  John   Doe ", "New\tYork"},
+		[]string{"JANE", " Caf\u0065\u0301 "},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name     string
+		opts     NormalizeOptions
+		expected [][]string
+	}{
+		{
+			name:     "no-op",
+			expected: dt.RowValues(),
+		},
+		{
+			name:     "trim space",
+			opts:     NormalizeOptions{TrimSpace: true},
+			expected: [][]string{{"John   Doe", "New\tYork"}, {"JANE", "Caf\u0065\u0301"}},
+		},
+		{
+			name:     "collapse inner whitespace",
+			opts:     NormalizeOptions{CollapseInnerWhitespace: true},
+			expected: [][]string{{" John Doe ", "New York"}, {"JANE", " Caf\u0065\u0301 "}},
+		},
+		{
+			name: "all",
+			opts: NormalizeOptions{
+				Transform: func(value string) string {
+					return strings.Replace(value, "\u0065\u0301", "\u00e9", -1)
+				},
+				TrimSpace:               true,
+				CollapseInnerWhitespace: true,
+				LowerCase:               true,
+			},
+			expected: [][]string{{"john doe", "new york"}, {"jane", "caf\u00e9"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := dt.Normalize(tc.opts); !reflect.DeepEqual(result.RowValues(), tc.expected) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expected, result.RowValues())
+			}
+		})
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "foo", "bar"},
+		[]string{"age", "42", "23"},
+		[]string{"city", "Berlin", "Paris"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	transposed, err := dt.Transpose()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "age", "city"}
+	expectedRows := [][]string{{"foo", "42", "Berlin"}, {"bar", "23", "Paris"}}
+
+	if !reflect.DeepEqual(transposed.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, transposed.Fields())
+	}
+
+	if !reflect.DeepEqual(transposed.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, transposed.RowValues())
+	}
+
+	original, err := transposed.Transpose()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !original.Equal(dt) {
+		t.Fatalf("expected double transpose to yield original data table, got %#v", original)
+	}
+}
+
+func TestTransposeDuplicateValues(t *testing.T) {
+	dt, err := New(
+		[]string{"key", "value"},
+		[]string{"tag", "foo"},
+		[]string{"tag", "bar"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	transposed, err := dt.Transpose()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"key", "tag", "tag"}
+
+	if !reflect.DeepEqual(transposed.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, transposed.Fields())
+	}
+
+	original, err := transposed.Transpose()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !original.Equal(dt) {
+		t.Fatalf("expected double transpose to yield original data table, got %#v", original)
+	}
+}
+
+func TestTransposeEmpty(t *testing.T) {
+	dt, err := New([]string{"name", "value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := dt.Transpose(); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestHeadAndTail(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := New(fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cases := []struct {
+		name     string
+		result   *DataTable
+		expected [][]string
+	}{
+		{name: "head", result: dt.Head(2), expected: rows[:2]},
+		{name: "head exceeding length", result: dt.Head(5), expected: rows},
+		{name: "head zero", result: dt.Head(0), expected: [][]string{}},
+		{name: "head negative", result: dt.Head(-1), expected: [][]string{}},
+		{name: "tail", result: dt.Tail(2), expected: rows[1:]},
+		{name: "tail exceeding length", result: dt.Tail(5), expected: rows},
+		{name: "tail zero", result: dt.Tail(0), expected: [][]string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.result.RowValues(), tc.expected) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expected, tc.result.RowValues())
+			}
+
+			if !reflect.DeepEqual(tc.result.Fields(), fields) {
+				t.Fatalf("expected fields %#v, got %#v", fields, tc.result.Fields())
+			}
+		})
+	}
+
+	head := dt.Head(1)
+	head.RowValues()[0][0] = "changed"
+
+	if dt.RowValues()[0][0] != "1" {
+		t.Fatal("expected receiver to be unchanged")
+	}
+}
+
+func TestSlice(t *testing.T) {
+	fields, rows := testData()
+
+	cases := []struct {
+		name        string
+		start, end  int
+		expected    [][]string
+		expectError bool
+	}{
+		{name: "range", start: 1, end: 3, expected: rows[1:3]},
+		{name: "all rows", start: 0, end: 3, expected: rows},
+		{name: "empty range", start: 2, end: 2, expected: [][]string{}},
+		{name: "negative start", start: -1, end: 2, expectError: true},
+		{name: "end out of bounds", start: 0, end: 4, expectError: true},
+		{name: "start greater than end", start: 2, end: 1, expectError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := New(fields, rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			result, err := dt.Slice(tc.start, tc.end)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(result.RowValues(), tc.expected) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expected, result.RowValues())
+			}
+
+			if !reflect.DeepEqual(result.Fields(), fields) {
+				t.Fatalf("expected fields %#v, got %#v", fields, result.Fields())
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	fields, rows := testData()
+
+	cases := []struct {
+		name           string
+		fields         []string
+		expectedFields []string
+		expectedRows   [][]string
+		expectError    bool
+	}{
+		{
+			name:           "subset",
+			fields:         []string{"three", "one"},
+			expectedFields: []string{"three", "one"},
+			expectedRows:   [][]string{{"3", "1"}, {"6", "4"}, {"9", "7"}},
+		},
+		{
+			name:           "no fields",
+			expectedFields: []string{},
+			expectedRows:   [][]string{{}, {}, {}},
+		},
+		{
+			name:        "unknown field",
+			fields:      []string{"one", "unknown"},
+			expectError: true,
+		},
+		{
+			name:        "duplicate field",
+			fields:      []string{"one", "one"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := New(fields, rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			result, err := dt.Select(tc.fields...)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(result.Fields(), tc.expectedFields) {
+				t.Fatalf("expected fields %#v, got %#v", tc.expectedFields, result.Fields())
+			}
+
+			if !reflect.DeepEqual(result.RowValues(), tc.expectedRows) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expectedRows, result.RowValues())
+			}
+
+			if !reflect.DeepEqual(dt.RowValues(), rows) {
+				t.Fatalf("expected receiver to be unchanged, got %#v", dt.RowValues())
+			}
+		})
+	}
+}
+
+func TestSelectDropsOptions(t *testing.T) {
+	fields, rows := testData()
+
+	dt, err := NewWithOptions(&Options{RequiredFields: []string{"one", "two"}}, fields, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	selected, err := dt.Select("one")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	subTable, err := dt.SubTable([]string{"three"}, 0, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	for _, result := range []*DataTable{selected, subTable} {
+		if result.Options() != nil {
+			t.Fatalf("expected nil options, got %#v", result.Options())
+		}
+
+		if err := result.Validate(); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "status"},
+		[]string{"foo", "active"},
+		[]string{"bar", "inactive"},
+		[]string{"foo", "active"},
+		[]string{"foo", "inactive"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{
+		{"foo", "active"},
+		{"bar", "inactive"},
+		{"foo", "inactive"},
+	}
+
+	if result := dt.Distinct(); !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	if dt.Len() != 4 {
+		t.Fatalf("expected receiver to be unchanged, got %d rows", dt.Len())
+	}
+}
+
+func TestDistinctBy(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "status"},
+		[]string{"foo", "active"},
+		[]string{"bar", "inactive"},
+		[]string{"foo", "inactive"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	result, err := dt.DistinctBy("name")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := [][]string{{"foo", "active"}, {"bar", "inactive"}}
+
+	if !reflect.DeepEqual(result.RowValues(), expected) {
+		t.Fatalf("expected rows %#v, got %#v", expected, result.RowValues())
+	}
+
+	if _, err := dt.DistinctBy("unknown"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	result, err = dt.DistinctBy()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(result.RowValues(), dt.RowValues()) {
+		t.Fatalf("expected rows %#v, got %#v", dt.RowValues(), result.RowValues())
+	}
+}
+
+func TestPivot(t *testing.T) {
+	dt, err := New(
+		[]string{"host", "metric", "value", "region"},
+		[]string{"a", "cpu", "10", "eu"},
+		[]string{"a", "mem", "20", "eu"},
+		[]string{"b", "cpu", "30", "us"},
+		[]string{"a", "disk", "40", "us"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	pivoted, err := dt.Pivot("metric", "value")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"host", "region", "cpu", "mem", "disk"}
+	expectedRows := [][]string{
+		{"a", "eu", "10", "20", ""},
+		{"b", "us", "30", "", ""},
+		{"a", "us", "", "", "40"},
+	}
+
+	if !reflect.DeepEqual(pivoted.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, pivoted.Fields())
+	}
+
+	if !reflect.DeepEqual(pivoted.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, pivoted.RowValues())
+	}
+}
+
+func TestPivotErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		rows       [][]string
+		keyField   string
+		valueField string
+	}{
+		{name: "unknown key field", keyField: "unknown", valueField: "value"},
+		{name: "unknown value field", keyField: "metric", valueField: "unknown"},
+		{name: "same field", keyField: "metric", valueField: "metric"},
+		{
+			name:       "key collides with field",
+			rows:       [][]string{{"a", "host", "1"}},
+			keyField:   "metric",
+			valueField: "value",
+		},
+		{
+			name:       "duplicate key in group",
+			rows:       [][]string{{"a", "cpu", "1"}, {"a", "cpu", "2"}},
+			keyField:   "metric",
+			valueField: "value",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := New([]string{"host", "metric", "value"}, tc.rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if _, err := dt.Pivot(tc.keyField, tc.valueField); err == nil {
+				t.Fatal("expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "status", "timestamp"},
+		[]string{"foo", "active", "1"},
+		[]string{"bar", "inactive", "2"},
+		[]string{"baz", "active", "3"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	view, err := dt.View([]string{"status", "name"}, func(row map[string]string) bool {
+		if _, ok := row["timestamp"]; ok {
+			t.Fatal("expected predicate to only receive selected fields")
+		}
+
+		return row["status"] == "active"
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"status", "name"}
+	expectedRows := [][]string{{"active", "foo"}, {"active", "baz"}}
+
+	if !reflect.DeepEqual(view.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, view.Fields())
+	}
+
+	if !reflect.DeepEqual(view.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, view.RowValues())
+	}
+
+	if _, err := dt.View([]string{"unknown"}, nil); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestSubTable(t *testing.T) {
+	fields, rows := testData()
+
+	cases := []struct {
+		name           string
+		fields         []string
+		start, end     int
+		expectedFields []string
+		expectedRows   [][]string
+		expectError    bool
+	}{
+		{
+			name:           "region",
+			fields:         []string{"three", "two"},
+			start:          1,
+			end:            3,
+			expectedFields: []string{"three", "two"},
+			expectedRows:   [][]string{{"6", "5"}, {"9", "8"}},
+		},
+		{
+			name:           "empty range",
+			fields:         []string{"one"},
+			start:          1,
+			end:            1,
+			expectedFields: []string{"one"},
+			expectedRows:   [][]string{},
+		},
+		{
+			name:        "unknown field",
+			fields:      []string{"unknown"},
+			start:       0,
+			end:         1,
+			expectError: true,
+		},
+		{
+			name:        "out of bounds",
+			fields:      []string{"one"},
+			start:       0,
+			end:         4,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dt, err := New(fields, rows...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			result, err := dt.SubTable(tc.fields, tc.start, tc.end)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(result.Fields(), tc.expectedFields) {
+				t.Fatalf("expected fields %#v, got %#v", tc.expectedFields, result.Fields())
+			}
+
+			if !reflect.DeepEqual(result.RowValues(), tc.expectedRows) {
+				t.Fatalf("expected rows %#v, got %#v", tc.expectedRows, result.RowValues())
+			}
+		})
+	}
+}
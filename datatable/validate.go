@@ -0,0 +1,480 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldType defines the type of the values of a field.
+type FieldType int
+
+// Supported field types.
+const (
+	String FieldType = iota
+	Int
+	Float
+	Bool
+)
+
+// String implements fmt.Stringer.
+func (t FieldType) String() string {
+	switch t {
+	case String:
+		return "string"
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case Bool:
+		return "bool"
+	default:
+		return fmt.Sprintf("FieldType(%d)", int(t))
+	}
+}
+
+// valid returns true if value can be parsed as the field type.
+func (t FieldType) valid(value string) bool {
+	var err error
+
+	switch t {
+	case Int:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case Float:
+		_, err = strconv.ParseFloat(value, 64)
+	case Bool:
+		_, err = strconv.ParseBool(value)
+	}
+
+	return err == nil
+}
+
+// ValidationError is returned if a data table violates its options. It
+// contains every problem that was found instead of just the first one.
+type ValidationError struct {
+	Errors []error
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// newValidationError returns a *ValidationError for errs or nil if errs is
+// empty.
+func newValidationError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: errs}
+}
+
+// Validate validates the current fields and rows of the data table against its
+// options. This is done automatically upon creation of the data table but can
+// be used to revalidate the data table after it was modified. If validation
+// fails, the returned error is a *ValidationError containing all problems.
+func (t *DataTable) Validate() error {
+	return validate(t.options, t.fields, t.rows)
+}
+
+// validate validates fields and rows against options and returns a
+// *ValidationError containing all problems or nil.
+func validate(options *Options, fields []string, rows [][]string) error {
+	errs := fieldErrors(options, fields)
+	errs = append(errs, rowErrors(options, fields, rows)...)
+
+	return newValidationError(errs)
+}
+
+// matchField returns true if a and b name the same field. Field names are
+// compared case-insensitively if the CaseInsensitiveFields option is set.
+func (o *Options) matchField(a, b string) bool {
+	if o != nil && o.CaseInsensitiveFields {
+		return strings.EqualFold(a, b)
+	}
+
+	return a == b
+}
+
+// indexOf returns the index of field in fields or -1 if fields does not
+// contain it.
+func (o *Options) indexOf(fields []string, field string) int {
+	for i, f := range fields {
+		if o.matchField(f, field) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// canonical returns the canonical name of field if it is listed in the
+// Aliases option. Otherwise field is returned unchanged.
+func (o *Options) canonical(field string) string {
+	if o == nil {
+		return field
+	}
+
+	for alias, name := range o.Aliases {
+		if o.matchField(alias, field) {
+			return name
+		}
+	}
+
+	return field
+}
+
+// canonicalFields returns a copy of fields where all aliased fields are
+// replaced by their canonical names.
+func (o *Options) canonicalFields(fields []string) []string {
+	if o == nil || len(o.Aliases) == 0 {
+		return fields
+	}
+
+	canonical := make([]string, len(fields))
+	for i, field := range fields {
+		canonical[i] = o.canonical(field)
+	}
+
+	return canonical
+}
+
+// trim returns copies of fields and rows with leading and trailing whitespace
+// removed from all field names and values if the Trim option is set.
+// Otherwise fields and rows are returned unchanged.
+func (o *Options) trim(fields []string, rows [][]string) ([]string, [][]string) {
+	if o == nil || !o.Trim {
+		return fields, rows
+	}
+
+	newRows := make([][]string, len(rows))
+	for i, row := range rows {
+		newRows[i] = trimValues(row)
+	}
+
+	return trimValues(fields), newRows
+}
+
+// trimValues returns a copy of values with leading and trailing whitespace
+// removed from every value.
+func trimValues(values []string) []string {
+	trimmed := make([]string, len(values))
+	for i, value := range values {
+		trimmed[i] = strings.TrimSpace(value)
+	}
+
+	return trimmed
+}
+
+// applyDefaults returns fields and copies of rows where all fields listed in
+// the Defaults option are present and their empty values are replaced by the
+// default value. Missing fields are appended in lexical order.
+func (o *Options) applyDefaults(fields []string, rows [][]string) ([]string, [][]string) {
+	if o == nil || len(o.Defaults) == 0 {
+		return fields, rows
+	}
+
+	names := make([]string, 0, len(o.Defaults))
+	for name := range o.Defaults {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fields = copyValues(fields)
+	defaults := make([]string, len(fields))
+
+	for _, name := range names {
+		if col := o.indexOf(fields, name); col >= 0 {
+			defaults[col] = o.Defaults[name]
+			continue
+		}
+
+		fields = append(fields, name)
+		defaults = append(defaults, o.Defaults[name])
+	}
+
+	newRows := make([][]string, len(rows))
+
+	for i, row := range rows {
+		newRow := make([]string, len(fields))
+		copy(newRow, row)
+
+		for j, value := range newRow {
+			if value == "" {
+				newRow[j] = defaults[j]
+			}
+		}
+
+		newRows[i] = newRow
+	}
+
+	return fields, newRows
+}
+
+// validateFields ensures that fields are unique unless the
+// AllowDuplicateFields option is set. If options are not nil, it also ensures
+// that required fields are present. If OptionalFields is not empty or the
+// Strict option is set, only fields listed in RequiredFields and
+// OptionalFields are allowed. If the CaseInsensitiveFields option is set,
+// fields that only differ in case are rejected. If the OrderedFields option is
+// set, fields must appear in the configured order. Returns a *ValidationError
+// containing all problems or nil.
+func validateFields(options *Options, fields []string) error {
+	return newValidationError(fieldErrors(options, fields))
+}
+
+// fieldErrors returns all problems found by validateFields.
+func fieldErrors(options *Options, fields []string) []error {
+	var errs []error
+
+	if options == nil || !options.AllowDuplicateFields {
+		if duplicates := duplicateFields(fields); len(duplicates) > 0 {
+			errs = append(errs, fmt.Errorf(`data table contains duplicate fields "%s"`, strings.Join(duplicates, `", "`)))
+		}
+	}
+
+	if options == nil {
+		return errs
+	}
+
+	if options.CaseInsensitiveFields {
+		for i, field := range fields {
+			if j := options.indexOf(fields[:i], field); j >= 0 && fields[j] != field {
+				errs = append(errs, fmt.Errorf(`data table contains fields %q and %q which only differ in case`, fields[j], field))
+			}
+		}
+	}
+
+	for _, field := range options.RequiredFields {
+		if options.indexOf(fields, field) < 0 {
+			errs = append(errs, fmt.Errorf(`data table is missing required field %q`, field))
+		}
+	}
+
+	if len(options.OrderedFields) > 0 && !options.matchOrder(fields) {
+		errs = append(errs, fmt.Errorf(
+			`data table fields "%s" do not match expected order "%s"`,
+			strings.Join(fields, `", "`),
+			strings.Join(options.OrderedFields, `", "`),
+		))
+	}
+
+	if len(options.OptionalFields) == 0 && (!options.Strict || len(options.RequiredFields) == 0) {
+		return errs
+	}
+
+	allowedFields := make([]string, 0, len(options.OptionalFields)+len(options.RequiredFields))
+	allowedFields = append(allowedFields, options.OptionalFields...)
+	allowedFields = append(allowedFields, options.RequiredFields...)
+
+	for _, field := range fields {
+		if options.indexOf(allowedFields, field) < 0 {
+			errs = append(errs, fmt.Errorf(
+				`data table contains additional field %q, allowed fields are "%s"`,
+				field,
+				strings.Join(allowedFields, `", "`),
+			))
+		}
+	}
+
+	return errs
+}
+
+// duplicateFields returns the names of all fields that occur more than once in
+// fields in order of their first duplicate occurrence.
+func duplicateFields(fields []string) []string {
+	duplicates := make([]string, 0)
+
+	for i, field := range fields {
+		if contains(fields[:i], field) && !contains(duplicates, field) {
+			duplicates = append(duplicates, field)
+		}
+	}
+
+	return duplicates
+}
+
+// matchOrder returns true if fields match the OrderedFields option. If the
+// OrderedFieldsPrefix option is set, fields only need to start with the
+// ordered fields.
+func (o *Options) matchOrder(fields []string) bool {
+	if len(fields) < len(o.OrderedFields) {
+		return false
+	}
+
+	if !o.OrderedFieldsPrefix && len(fields) != len(o.OrderedFields) {
+		return false
+	}
+
+	for i, field := range o.OrderedFields {
+		if !o.matchField(fields[i], field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// constraints holds the constraints for the values of a single field.
+type constraints struct {
+	required  bool
+	nonEmpty  bool
+	fieldType *FieldType
+	pattern   *regexp.Regexp
+}
+
+// fieldConstraints returns the constraints for the values of field as
+// configured via the RequiredFields, NonEmptyFields, FieldTypes and
+// FieldPatterns options.
+func (o *Options) fieldConstraints(field string) constraints {
+	c := constraints{
+		required: o.indexOf(o.RequiredFields, field) >= 0,
+		nonEmpty: o.indexOf(o.NonEmptyFields, field) >= 0,
+	}
+
+	for name, fieldType := range o.FieldTypes {
+		if o.matchField(name, field) {
+			fieldType := fieldType
+			c.fieldType = &fieldType
+		}
+	}
+
+	for name, pattern := range o.FieldPatterns {
+		if o.matchField(name, field) {
+			c.pattern = pattern
+		}
+	}
+
+	return c
+}
+
+// rowErrors ensures that values of fields listed in the NonEmptyFields option
+// are not empty, that all non-empty values of fields listed in the FieldTypes
+// option can be parsed as the configured type and that values of fields listed
+// in the FieldPatterns option match the configured pattern if options are not
+// nil. Returns all problems found.
+func rowErrors(options *Options, fields []string, rows [][]string) []error {
+	if options == nil {
+		return nil
+	}
+
+	var errs []error
+
+	cs := make([]constraints, len(fields))
+	anchored := make([]*regexp.Regexp, len(fields))
+
+	for j, field := range fields {
+		cs[j] = options.fieldConstraints(field)
+
+		if cs[j].pattern != nil {
+			anchored[j] = anchorPattern(cs[j].pattern)
+		}
+	}
+
+	for i, row := range rows {
+		for j, field := range fields {
+			if err := validateValue(cs[j], anchored[j], row[j]); err != nil {
+				errs = append(errs, fmt.Errorf("row %d, field %q: %s", i, field, err.Error()))
+			}
+		}
+	}
+
+	return append(errs, uniqueErrors(options, fields, rows)...)
+}
+
+// validateValue validates value against c. The anchored pattern is used for
+// matching while the original pattern is used for error messages.
+func validateValue(c constraints, anchored *regexp.Regexp, value string) error {
+	if c.nonEmpty && value == "" {
+		return errors.New("value must not be empty")
+	}
+
+	if c.fieldType != nil && value != "" && !c.fieldType.valid(value) {
+		return fmt.Errorf("value %q is not a valid %s", value, *c.fieldType)
+	}
+
+	if c.pattern == nil || (value == "" && !c.required) {
+		return nil
+	}
+
+	if !anchored.MatchString(value) {
+		return fmt.Errorf("value %q does not match pattern %q", value, c.pattern.String())
+	}
+
+	return nil
+}
+
+// uniqueErrors reports every row that shares the same values for the fields
+// listed in the UniqueFields and UniqueKeys options with a previous row.
+// Unique keys that reference fields which are not present are ignored.
+func uniqueErrors(options *Options, fields []string, rows [][]string) []error {
+	var errs []error
+
+	keys := make([][]string, 0, len(options.UniqueFields)+len(options.UniqueKeys))
+	for _, field := range options.UniqueFields {
+		keys = append(keys, []string{field})
+	}
+
+	keys = append(keys, options.UniqueKeys...)
+
+	for _, key := range keys {
+		cols, ok := options.indicesOf(fields, key)
+		if !ok {
+			continue
+		}
+
+		seen := make(map[string]int, len(rows))
+
+		for i, row := range rows {
+			values := columnValues(row, cols)
+			k := compositeKey(values)
+
+			if first, ok := seen[k]; ok {
+				errs = append(errs, fmt.Errorf(
+					`duplicate value "%s" for field(s) "%s" in rows %d and %d`,
+					strings.Join(values, `", "`),
+					strings.Join(key, `", "`),
+					first,
+					i,
+				))
+
+				continue
+			}
+
+			seen[k] = i
+		}
+	}
+
+	return errs
+}
+
+// indicesOf returns the indices of names in fields. The second return value
+// is false if any of the names is not present in fields.
+func (o *Options) indicesOf(fields, names []string) ([]int, bool) {
+	indices := make([]int, len(names))
+
+	for i, name := range names {
+		indices[i] = o.indexOf(fields, name)
+		if indices[i] < 0 {
+			return nil, false
+		}
+	}
+
+	return indices, true
+}
+
+// anchorPattern returns a copy of pattern that only matches if the whole input
+// matches.
+func anchorPattern(pattern *regexp.Regexp) *regexp.Regexp {
+	return regexp.MustCompile(`^(?:` + pattern.String() + `)$`)
+}
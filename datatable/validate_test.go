@@ -0,0 +1,694 @@
+package datatable
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFieldTypes(t *testing.T) {
+	options := &Options{
+		FieldTypes: map[string]FieldType{
+			"name":    String,
+			"count":   Int,
+			"ratio":   Float,
+			"enabled": Bool,
+		},
+	}
+
+	fields := []string{"name", "count", "ratio", "enabled"}
+
+	cases := []struct {
+		name        string
+		rows        [][]string
+		expectedErr string
+	}{
+		{
+			name: "valid values",
+			rows: [][]string{
+				{"foo", "1", "0.5", "true"},
+				{"bar", "-2", "3", "0"},
+			},
+		},
+		{
+			name: "empty values",
+			rows: [][]string{{"", "", "", ""}},
+		},
+		{
+			name: "invalid int",
+			rows: [][]string{
+				{"foo", "1", "0.5", "true"},
+				{"bar", "1.5", "3", "false"},
+			},
+			expectedErr: `row 1, field "count": value "1.5" is not a valid int`,
+		},
+		{
+			name:        "invalid float",
+			rows:        [][]string{{"foo", "1", "half", "true"}},
+			expectedErr: `row 0, field "ratio": value "half" is not a valid float`,
+		},
+		{
+			name:        "invalid bool",
+			rows:        [][]string{{"foo", "1", "0.5", "yes"}},
+			expectedErr: `row 0, field "enabled": value "yes" is not a valid bool`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWithOptions(options, fields, tc.rows...)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				if err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %q", tc.expectedErr, err.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestFieldPatterns(t *testing.T) {
+	options := &Options{
+		RequiredFields: []string{"id"},
+		FieldPatterns: map[string]*regexp.Regexp{
+			"id":    regexp.MustCompile(`[0-9]+`),
+			"email": regexp.MustCompile(`[^@]+@[^@]+|foo`),
+		},
+	}
+
+	fields := []string{"id", "email"}
+
+	cases := []struct {
+		name        string
+		rows        [][]string
+		expectedErr string
+	}{
+		{
+			name: "valid values",
+			rows: [][]string{
+				{"1", "foo@example.com"},
+				{"23", "foo"},
+			},
+		},
+		{
+			name: "empty optional value",
+			rows: [][]string{{"1", ""}},
+		},
+		{
+			name:        "empty required value",
+			rows:        [][]string{{"", "foo@example.com"}},
+			expectedErr: `row 0, field "id": value "" does not match pattern "[0-9]+"`,
+		},
+		{
+			name:        "partial match",
+			rows:        [][]string{{"1", "foo@example.com"}, {"12a", "foo@example.com"}},
+			expectedErr: `row 1, field "id": value "12a" does not match pattern "[0-9]+"`,
+		},
+		{
+			name:        "partial match with alternation",
+			rows:        [][]string{{"1", "foobar"}},
+			expectedErr: `row 0, field "email": value "foobar" does not match pattern "[^@]+@[^@]+|foo"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWithOptions(options, fields, tc.rows...)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				if err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %q", tc.expectedErr, err.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestUniqueFields(t *testing.T) {
+	fields := []string{"id", "name", "tag"}
+
+	cases := []struct {
+		name        string
+		options     *Options
+		rows        [][]string
+		expectedErr string
+	}{
+		{
+			name:    "unique fields",
+			options: &Options{UniqueFields: []string{"id", "name"}},
+			rows: [][]string{
+				{"1", "foo", "a"},
+				{"2", "bar", "a"},
+			},
+		},
+		{
+			name:    "duplicate value",
+			options: &Options{UniqueFields: []string{"id", "name"}},
+			rows: [][]string{
+				{"1", "foo", "a"},
+				{"2", "bar", "a"},
+				{"3", "foo", "b"},
+			},
+			expectedErr: `duplicate value "foo" for field(s) "name" in rows 0 and 2`,
+		},
+		{
+			name:    "unique composite key",
+			options: &Options{UniqueKeys: [][]string{{"name", "tag"}}},
+			rows: [][]string{
+				{"1", "foo", "a"},
+				{"2", "foo", "b"},
+				{"3", "bar", "a"},
+			},
+		},
+		{
+			name:    "duplicate composite key",
+			options: &Options{UniqueKeys: [][]string{{"name", "tag"}}},
+			rows: [][]string{
+				{"1", "foo", "a"},
+				{"2", "foo", "b"},
+				{"3", "foo", "a"},
+			},
+			expectedErr: `duplicate value "foo", "a" for field(s) "name", "tag" in rows 0 and 2`,
+		},
+		{
+			name:    "composite key with NUL characters",
+			options: &Options{UniqueKeys: [][]string{{"name", "tag"}}},
+			rows: [][]string{
+				{"1", "x\x00", "y"},
+				{"2", "x", "\x00y"},
+			},
+		},
+		{
+			name:    "unknown field",
+			options: &Options{UniqueFields: []string{"unknown"}},
+			rows: [][]string{
+				{"1", "foo", "a"},
+				{"1", "foo", "a"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWithOptions(tc.options, fields, tc.rows...)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				if err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %q", tc.expectedErr, err.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestCaseInsensitiveFields(t *testing.T) {
+	options := &Options{
+		CaseInsensitiveFields: true,
+		RequiredFields:        []string{"name"},
+		OptionalFields:        []string{"value"},
+		FieldTypes:            map[string]FieldType{"value": Int},
+	}
+
+	dt, err := NewWithOptions(options, []string{"Name", "VALUE"}, []string{"foo", "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	value, err := dt.GetCell(0, "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if value != "foo" {
+		t.Fatalf("expected value %q, got %q", "foo", value)
+	}
+
+	if _, err := dt.Column("Value"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.AppendColumn("NAME", ""); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.RenameColumn("name", "NAME"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := NewWithOptions(options, []string{"Name", "value"}, []string{"foo", "bar"}); err == nil {
+		t.Fatal("expected field type error but got nil")
+	}
+
+	_, err = NewWithOptions(options, []string{"Name", "name"}, []string{"foo", "bar"})
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	expected := `data table contains fields "Name" and "name" which only differ in case`
+
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestCaseSensitiveFields(t *testing.T) {
+	options := &Options{RequiredFields: []string{"name"}}
+
+	if _, err := NewWithOptions(options, []string{"Name"}, []string{"foo"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	dt, err := NewWithOptions(nil, []string{"Name"}, []string{"foo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if _, err := dt.GetCell(0, "name"); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestAliases(t *testing.T) {
+	options := &Options{
+		RequiredFields: []string{"id", "name"},
+		Aliases: map[string]string{
+			"ID":         "id",
+			"identifier": "id",
+		},
+	}
+
+	fields := []string{"identifier", "name"}
+
+	dt, err := NewWithOptions(options, fields, []string{"1", "foo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{"id", "name"}
+
+	if !reflect.DeepEqual(dt.Fields(), expected) {
+		t.Fatalf("expected fields %#v, got %#v", expected, dt.Fields())
+	}
+
+	if fields[0] != "identifier" {
+		t.Fatal("expected fields passed to constructor to be unchanged")
+	}
+
+	for _, field := range []string{"id", "ID", "identifier"} {
+		value, err := dt.GetCell(0, field)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if value != "1" {
+			t.Fatalf("expected value %q for field %q, got %q", "1", field, value)
+		}
+	}
+
+	if err := dt.AppendColumn("ID", ""); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if _, err := NewWithOptions(options, []string{"key", "name"}); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	options := &Options{
+		RequiredFields: []string{"name", "status"},
+		Defaults: map[string]string{
+			"status": "active",
+			"tag":    "none",
+			"value":  "0",
+		},
+	}
+
+	rows := [][]string{{"foo", ""}, {"bar", "42"}}
+
+	dt, err := NewWithOptions(options, []string{"name", "value"}, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "value", "status", "tag"}
+	expectedRows := [][]string{
+		{"foo", "0", "active", "none"},
+		{"bar", "42", "active", "none"},
+	}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+
+	if rows[0][1] != "" {
+		t.Fatal("expected rows passed to constructor to be unchanged")
+	}
+}
+
+func TestNonEmptyFields(t *testing.T) {
+	options := &Options{NonEmptyFields: []string{"name", "missing"}}
+
+	_, err := NewWithOptions(options, []string{"name", "value"}, []string{"foo", ""}, []string{"bar", "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	_, err = NewWithOptions(
+		options,
+		[]string{"name", "value"},
+		[]string{"foo", "1"},
+		[]string{"", "2"},
+		[]string{"", "3"},
+	)
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	expected := `row 1, field "name": value must not be empty; row 2, field "name": value must not be empty`
+
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestStrict(t *testing.T) {
+	cases := []struct {
+		name        string
+		options     *Options
+		fields      []string
+		expectError bool
+	}{
+		{
+			name:    "exact fields",
+			options: &Options{RequiredFields: []string{"name", "value"}, Strict: true},
+			fields:  []string{"value", "name"},
+		},
+		{
+			name:        "additional field",
+			options:     &Options{RequiredFields: []string{"name", "value"}, Strict: true},
+			fields:      []string{"name", "value", "extra"},
+			expectError: true,
+		},
+		{
+			name:    "additional field without strict",
+			options: &Options{RequiredFields: []string{"name", "value"}},
+			fields:  []string{"name", "value", "extra"},
+		},
+		{
+			name:    "optional field",
+			options: &Options{RequiredFields: []string{"name"}, OptionalFields: []string{"value"}, Strict: true},
+			fields:  []string{"name", "value"},
+		},
+		{
+			name:    "no required or optional fields",
+			options: &Options{Strict: true},
+			fields:  []string{"name", "value"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWithOptions(tc.options, tc.fields)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestOrderedFields(t *testing.T) {
+	cases := []struct {
+		name        string
+		options     *Options
+		fields      []string
+		expectedErr string
+	}{
+		{
+			name:    "exact order",
+			options: &Options{OrderedFields: []string{"id", "name"}},
+			fields:  []string{"id", "name"},
+		},
+		{
+			name:        "wrong order",
+			options:     &Options{OrderedFields: []string{"id", "name"}},
+			fields:      []string{"name", "id"},
+			expectedErr: `data table fields "name", "id" do not match expected order "id", "name"`,
+		},
+		{
+			name:        "additional field",
+			options:     &Options{OrderedFields: []string{"id", "name"}},
+			fields:      []string{"id", "name", "extra"},
+			expectedErr: `data table fields "id", "name", "extra" do not match expected order "id", "name"`,
+		},
+		{
+			name:    "prefix",
+			options: &Options{OrderedFields: []string{"id", "name"}, OrderedFieldsPrefix: true},
+			fields:  []string{"id", "name", "extra"},
+		},
+		{
+			name:        "prefix with missing field",
+			options:     &Options{OrderedFields: []string{"id", "name"}, OrderedFieldsPrefix: true},
+			fields:      []string{"id"},
+			expectedErr: `data table fields "id" do not match expected order "id", "name"`,
+		},
+		{
+			name:        "prefix in wrong order",
+			options:     &Options{OrderedFields: []string{"id", "name"}, OrderedFieldsPrefix: true},
+			fields:      []string{"extra", "id", "name"},
+			expectedErr: `data table fields "extra", "id", "name" do not match expected order "id", "name"`,
+		},
+		{
+			name:    "case-insensitive",
+			options: &Options{OrderedFields: []string{"id", "name"}, CaseInsensitiveFields: true},
+			fields:  []string{"ID", "Name"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWithOptions(tc.options, tc.fields)
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+
+				if err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %q", tc.expectedErr, err.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestDuplicateFields(t *testing.T) {
+	cases := []struct {
+		name     string
+		options  *Options
+		fields   []string
+		expected string
+	}{
+		{
+			name:     "regression: duplicate field without options",
+			fields:   []string{"a", "a"},
+			expected: `data table contains duplicate fields "a"`,
+		},
+		{
+			name:     "multiple duplicate fields",
+			options:  &Options{},
+			fields:   []string{"name", "value", "name", "value", "name"},
+			expected: `data table contains duplicate fields "name", "value"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWithOptions(tc.options, tc.fields)
+			if err == nil {
+				t.Fatal("expected error but got nil")
+			}
+
+			if err.Error() != tc.expected {
+				t.Fatalf("expected error %q, got %q", tc.expected, err.Error())
+			}
+		})
+	}
+
+	dt, err := NewWithOptions(
+		&Options{AllowDuplicateFields: true},
+		[]string{"name", "value", "name", "name_2"},
+		[]string{"foo", "1", "bar", "baz"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedRows := []map[string]string{
+		{"name": "foo", "value": "1", "name_2": "bar", "name_2_2": "baz"},
+	}
+
+	if !reflect.DeepEqual(dt.Rows(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.Rows())
+	}
+
+	row, err := dt.RowAt(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(row, expectedRows[0]) {
+		t.Fatalf("expected row %#v, got %#v", expectedRows[0], row)
+	}
+
+	expectedValues := [][]string{{"foo", "1", "bar", "baz"}}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedValues) {
+		t.Fatalf("expected row values %#v, got %#v", expectedValues, dt.RowValues())
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	options := &Options{
+		RequiredFields: []string{"name", "id"},
+		OptionalFields: []string{"count"},
+		FieldTypes:     map[string]FieldType{"count": Int},
+		UniqueFields:   []string{"name"},
+	}
+
+	_, err := NewWithOptions(
+		options,
+		[]string{"name", "count", "extra"},
+		[]string{"foo", "1", ""},
+		[]string{"bar", "x", ""},
+		[]string{"foo", "y", ""},
+	)
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+
+	expected := []string{
+		`data table is missing required field "id"`,
+		`data table contains additional field "extra", allowed fields are "count", "name", "id"`,
+		`row 1, field "count": value "x" is not a valid int`,
+		`row 2, field "count": value "y" is not a valid int`,
+		`duplicate value "foo" for field(s) "name" in rows 0 and 2`,
+	}
+
+	if len(verr.Errors) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %s", len(expected), len(verr.Errors), err.Error())
+	}
+
+	for i, msg := range expected {
+		if verr.Errors[i].Error() != msg {
+			t.Fatalf("expected error %d to be %q, got %q", i, msg, verr.Errors[i].Error())
+		}
+	}
+
+	if len(options.OptionalFields) != 1 {
+		t.Fatalf("expected options to be unchanged, got %#v", options.OptionalFields)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	options := &Options{
+		RequiredFields: []string{"name"},
+		FieldTypes:     map[string]FieldType{"value": Int},
+		Defaults:       map[string]string{"value": "0"},
+		Trim:           true,
+	}
+
+	rows := [][]string{{" foo ", " 42"}, {"bar", "  "}}
+
+	dt, err := NewWithOptions(options, []string{" name", "value "}, rows...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expectedFields := []string{"name", "value"}
+	expectedRows := [][]string{{"foo", "42"}, {"bar", "0"}}
+
+	if !reflect.DeepEqual(dt.Fields(), expectedFields) {
+		t.Fatalf("expected fields %#v, got %#v", expectedFields, dt.Fields())
+	}
+
+	if !reflect.DeepEqual(dt.RowValues(), expectedRows) {
+		t.Fatalf("expected rows %#v, got %#v", expectedRows, dt.RowValues())
+	}
+
+	if rows[0][0] != " foo " {
+		t.Fatal("expected rows passed to constructor to be unchanged")
+	}
+
+	options.Trim = false
+
+	if _, err := NewWithOptions(options, []string{" name", "value "}, rows...); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	options := &Options{
+		RequiredFields: []string{"name"},
+		FieldTypes:     map[string]FieldType{"value": Int},
+		UniqueFields:   []string{"name"},
+	}
+
+	dt, err := NewWithOptions(options, []string{"name", "value"}, []string{"foo", "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.AppendRow([]string{"bar", "two"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.Validate(); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.UpdateRow(1, []string{"foo", "2"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.Validate(); err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	if err := dt.UpdateRow(1, []string{"bar", "2"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := dt.Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+}
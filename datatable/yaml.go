@@ -0,0 +1,24 @@
+package datatable
+
+import (
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ToYAML transforms the data table rows into a yaml sequence of mappings. The
+// keys of each mapping are the data table fields in their original order.
+// Duplicate fields are suffixed like in Rows.
+func (t *DataTable) ToYAML() ([]byte, error) {
+	rows := make([]yaml.MapSlice, len(t.rows))
+	keys := t.mapKeys()
+
+	for i, row := range t.rows {
+		m := make(yaml.MapSlice, len(keys))
+		for j, key := range keys {
+			m[j] = yaml.MapItem{Key: key, Value: row[j]}
+		}
+
+		rows[i] = m
+	}
+
+	return yaml.Marshal(rows)
+}
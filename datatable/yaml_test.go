@@ -0,0 +1,35 @@
+package datatable
+
+import (
+	"testing"
+)
+
+func TestToYAML(t *testing.T) {
+	dt, err := New(
+		[]string{"name", "value", "count"},
+		[]string{"foo", "bar", "1"},
+		[]string{"baz", "multi\nline", "true"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	buf, err := dt.ToYAML()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `- name: foo
+  value: bar
+  count: "1"
+- name: baz
+  value: |-
+    multi
+    line
+  count: "true"
+`
+
+	if string(buf) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, string(buf))
+	}
+}
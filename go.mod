@@ -4,6 +4,6 @@ go 1.12
 
 require (
 	github.com/DATA-DOG/godog v0.7.13
-	github.com/jinzhu/copier v0.0.0-20180308034124-7e38e58719c3
 	github.com/tidwall/pretty v0.0.0-20180105212114-65a9db5fad51
+	gopkg.in/yaml.v2 v2.2.2
 )